@@ -3,7 +3,12 @@ package binaries
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/binaries/bundle"
 	"github.com/dymensionxyz/roller/cmd/binaries/install"
+	"github.com/dymensionxyz/roller/cmd/binaries/list"
+	"github.com/dymensionxyz/roller/cmd/binaries/pin"
+	"github.com/dymensionxyz/roller/cmd/binaries/rollback"
+	"github.com/dymensionxyz/roller/cmd/binaries/verify"
 )
 
 func Cmd() *cobra.Command {
@@ -12,7 +17,12 @@ func Cmd() *cobra.Command {
 		Short: "Commands to manage roller dependencies",
 	}
 
+	cmd.AddCommand(bundle.Cmd())
 	cmd.AddCommand(install.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(verify.Cmd())
+	cmd.AddCommand(pin.Cmd())
+	cmd.AddCommand(rollback.Cmd())
 
 	return cmd
 }
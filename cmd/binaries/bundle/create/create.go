@@ -0,0 +1,127 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <rollapp-id>",
+		Short: "Create a bundle with all binaries required to run a RollApp node",
+		Long: `Create a bundle with all binaries required to run a RollApp node.
+
+The binaries are built or downloaded on this host and written together with their
+SHA-256 checksums into a .tar.gz archive. Install it on a host without network access
+with 'roller binaries install --from-bundle'. The bundle targets the operating system
+and architecture of this host. Versions pinned in roller.toml are respected.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			raID := strings.TrimSpace(args[0])
+			node, _ := cmd.Flags().GetString("node")
+			chainID, _ := cmd.Flags().GetString("chain-id")
+			output, _ := cmd.Flags().GetString("output")
+
+			if output == "" {
+				output = fmt.Sprintf("roller-bundle-%s.tar.gz", raID)
+			}
+			// the binaries are built in temporary directories, a relative
+			// output path wouldn't point to the working directory anymore
+			output, err = filesystem.ExpandHomePath(output)
+			if err == nil {
+				output, err = filepath.Abs(output)
+			}
+			if err != nil {
+				pterm.Error.Println("invalid output path: ", err)
+				return
+			}
+
+			err = checkWritable(filepath.Dir(output))
+			if err != nil {
+				pterm.Error.Println("the bundle can't be written: ", err)
+				return
+			}
+
+			hd := consts.PlaygroundHubData
+			hd.RPC_URL = node
+			hd.ID = chainID
+
+			// roller doesn't have to be initialized to create a bundle
+			rollerData, _ := roller.LoadConfig(home)
+
+			dymd := dependencies.DymdDependency(rollerData.Binaries)
+			if !dependencies.IsInstalled(dymd) {
+				err = dependencies.InstallBinaryFromRelease(dymd)
+				if err != nil {
+					pterm.Error.Println("failed to install dymd: ", err)
+					return
+				}
+			}
+
+			raResponse, err := rollapp.Show(raID, hd)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve rollapp information: ", err)
+				return
+			}
+
+			bm, err := dependencies.CreateBundle(*raResponse, rollerData.Binaries, output)
+			if err != nil {
+				pterm.Error.Println("failed to create the bundle: ", err)
+				return
+			}
+
+			td := pterm.TableData{{"Binary", "Version", "SHA-256"}}
+			for _, b := range bm.Binaries {
+				td = append(td, []string{b.Name, b.Version, b.SHA256})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+
+			pterm.Success.Printf("bundle for %s/%s written to %s\n", bm.OS, bm.Arch, output)
+			pterm.Info.Println("next steps:")
+			pterm.Info.Printf(
+				"copy the bundle to the target host and run %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller binaries install --from-bundle %s", output),
+			)
+		},
+	}
+
+	cmd.Flags().String("node", consts.PlaygroundHubData.RPC_URL, "hub rpc endpoint")
+	cmd.Flags().String("chain-id", consts.PlaygroundHubData.ID, "hub chain id")
+	cmd.Flags().StringP("output", "o", "", "path of the bundle, roller-bundle-<rollapp-id>.tar.gz by default")
+
+	return cmd
+}
+
+// checkWritable fails when files can't be created in the directory, so the
+// bundle isn't built in vain
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".roller-bundle-*")
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	f.Close()
+
+	return os.Remove(f.Name())
+}
@@ -1,10 +1,16 @@
 package install
 
 import (
+	"fmt"
+
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/upgrades"
 )
 
 func Cmd() *cobra.Command {
@@ -13,6 +19,20 @@ func Cmd() *cobra.Command {
 		Short: "Install necessary binaries for operating a RollApp node",
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			bundle, _ := cmd.Flags().GetString("from-bundle")
+			if bundle != "" {
+				home, err := filesystem.ExpandHomePath(
+					cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+				)
+				if err != nil {
+					pterm.Error.Println("failed to expand home directory")
+					return
+				}
+
+				installFromBundle(home, bundle)
+				return
+			}
+
 			pterm.Info.Println("not implemented")
 			// home := cmd.Flag(utils.FlagNames.Home).Value.String()
 			//
@@ -100,6 +120,27 @@ func Cmd() *cobra.Command {
 
 	cmd.Flags().String("node", consts.PlaygroundHubData.RPC_URL, "hub rpc endpoint")
 	cmd.Flags().String("chain-id", consts.PlaygroundHubData.ID, "hub chain id")
+	cmd.Flags().String(
+		"from-bundle",
+		"",
+		"install from a bundle created with 'roller binaries bundle create', no network access is required",
+	)
 
 	return cmd
 }
+
+// installFromBundle installs the binaries of the bundle, a managed rollapp
+// binary is staged as an upgrade
+func installFromBundle(home, path string) {
+	spinner, _ := pterm.DefaultSpinner.Start("installing binaries from " + path)
+	bm, err := dependencies.InstallBundle(path, upgrades.BinaryStager(home))
+	if err != nil {
+		spinner.Fail("failed to install the bundle: ", err)
+		return
+	}
+	spinner.Success(fmt.Sprintf("binaries for %s installed", bm.RollappID))
+
+	for _, b := range bm.Binaries {
+		pterm.Info.Printf("%s %s (sha256 %s)\n", b.Name, b.Version, b.SHA256)
+	}
+}
@@ -0,0 +1,95 @@
+package list
+
+import (
+	"os"
+	"sort"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the installed binaries with their versions and paths",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			m, err := dependencies.LoadManifest()
+			if err != nil {
+				pterm.Error.Println("failed to load the binaries manifest:", err)
+				return
+			}
+
+			// roller doesn't have to be initialized to list the binaries
+			rollerData, _ := roller.LoadConfig(home)
+
+			tracked := dependencies.TrackedExecutables()
+			var names []string
+			for name := range tracked {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			td := pterm.TableData{
+				{"Binary", "Installed", "Commit", "Expected", "Previous", "Path", "Status"},
+			}
+			for _, name := range names {
+				path := tracked[name]
+
+				expected := dependencies.DefaultReleases[name]
+				if name == "rollappd" {
+					expected = rollerData.RollappBinaryVersion
+				}
+				pinned, isPinned := rollerData.Binaries[name]
+				if isPinned {
+					expected = pinned + " (pinned)"
+				}
+
+				installed, commit, previous := "-", "-", "-"
+				if ib, ok := m.Binaries[name]; ok {
+					installed = ib.Current.Version
+					if ib.Current.Commit != "" {
+						commit = shortCommit(ib.Current.Commit)
+					}
+					if ib.Previous != nil {
+						previous = ib.Previous.Version
+					}
+				}
+
+				var status string
+				if _, err := os.Stat(path); err != nil {
+					status = "missing"
+				} else if installed == "-" {
+					status = "untracked"
+				} else if isPinned && pinned != installed {
+					status = "pin mismatch"
+				}
+
+				td = append(td, []string{name, installed, commit, expected, previous, path, status})
+			}
+
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
+
+func shortCommit(c string) string {
+	if len(c) > 8 {
+		return c[:8]
+	}
+	return c
+}
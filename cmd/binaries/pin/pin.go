@@ -0,0 +1,101 @@
+package pin
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin <binary> [version]",
+		Short: "Lock the version of a binary in roller.toml",
+		Long: `Lock the version of a binary in roller.toml.
+
+Pinned versions are installed instead of the defaults and the rollapp version from the
+genesis file the next time the binaries are installed. Without a version the currently
+installed version is pinned.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			name := args[0]
+			if _, ok := dependencies.TrackedExecutables()[name]; !ok {
+				pterm.Error.Printf("unknown binary %s\n", name)
+				return
+			}
+
+			rollerConfigFilePath := roller.GetConfigPath(home)
+			// migrate roller.toml before editing it in place
+			_, err = roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			remove, _ := cmd.Flags().GetBool("remove")
+			key := fmt.Sprintf("binaries.%s", name)
+			if remove {
+				err = tomlconfig.RemoveFieldFromFile(rollerConfigFilePath, key)
+				if err != nil {
+					pterm.Error.Printf("failed to unpin %s: %v\n", name, err)
+					return
+				}
+				pterm.Success.Printf("%s unpinned\n", name)
+				return
+			}
+
+			var v string
+			if len(args) == 2 {
+				v = args[1]
+			} else {
+				m, err := dependencies.LoadManifest()
+				if err != nil {
+					pterm.Error.Println("failed to load the binaries manifest:", err)
+					return
+				}
+
+				ib, ok := m.Binaries[name]
+				if !ok {
+					pterm.Error.Printf(
+						"%s is not installed by roller, provide the version to pin\n",
+						name,
+					)
+					return
+				}
+				v = ib.Current.Version
+			}
+
+			err = tomlconfig.UpdateFieldInFile(rollerConfigFilePath, key, v)
+			if err != nil {
+				pterm.Error.Printf("failed to pin %s: %v\n", name, err)
+				return
+			}
+
+			pterm.Success.Printf(
+				"%s pinned to %s in %s\n",
+				name,
+				v,
+				filepath.Base(rollerConfigFilePath),
+			)
+		},
+	}
+
+	cmd.Flags().Bool("remove", false, "remove the pin of the binary")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package rollback
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/upgrades"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <binary>",
+		Short: "Restore the previously installed version of a binary",
+		Long: `Restore the previously installed version of a binary from the versioned cache.
+
+The services using the binary have to be restarted afterwards.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			name := args[0]
+			if name == "rollappd" && upgrades.IsManaged(home) {
+				pterm.Error.Printf(
+					"the rollapp binary is managed by upgrades, use %s instead\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp upgrade rollback"),
+				)
+				return
+			}
+
+			ib, err := dependencies.Rollback(name)
+			if err != nil {
+				pterm.Error.Printf("failed to roll back %s: %v\n", name, err)
+				return
+			}
+
+			pterm.Success.Printf(
+				"%s rolled back from %s to %s\n",
+				ib.Name,
+				ib.Previous.Version,
+				ib.Current.Version,
+			)
+			pterm.Info.Println("restart the services using the binary to apply the change")
+		},
+	}
+
+	return cmd
+}
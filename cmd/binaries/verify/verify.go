@@ -0,0 +1,59 @@
+package verify
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/utils/dependencies"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the SHA-256 checksums of the installed binaries against the manifest",
+		Run: func(cmd *cobra.Command, args []string) {
+			m, err := dependencies.LoadManifest()
+			if err != nil {
+				pterm.Error.Println("failed to load the binaries manifest:", err)
+				return
+			}
+
+			if len(m.Binaries) == 0 {
+				pterm.Info.Printf("no binaries recorded in %s\n", dependencies.ManifestPath())
+				return
+			}
+
+			results, err := m.Verify()
+			if err != nil {
+				pterm.Error.Println("failed to verify the binaries:", err)
+				return
+			}
+
+			var failed int
+			for _, r := range results {
+				switch {
+				case r.Missing:
+					failed++
+					pterm.Error.Printf("%s: missing from %s\n", r.Binary.Name, r.Binary.Path)
+				case r.Modified:
+					failed++
+					pterm.Error.Printf(
+						"%s: checksum mismatch, have: %s, want: %s\n",
+						r.Binary.Name,
+						r.SHA256,
+						r.Binary.Current.SHA256,
+					)
+				default:
+					pterm.Success.Printf("%s %s: ok\n", r.Binary.Name, r.Binary.Current.Version)
+				}
+			}
+
+			if failed > 0 {
+				pterm.Error.Printf("%d of %d binaries failed verification\n", failed, len(results))
+				return
+			}
+		},
+	}
+
+	return cmd
+}
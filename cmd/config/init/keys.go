@@ -12,8 +12,11 @@ import (
 func FormatAddresses(
 	raCfg roller.RollappConfig,
 	addresses []keys.KeyInfo,
-) []keys.KeyInfo {
-	damanager := datalayer.NewDAManager(raCfg.DA.Backend, raCfg.Home)
+) ([]keys.KeyInfo, error) {
+	damanager, err := datalayer.NewDAManager(raCfg.DA.Backend, raCfg.Home)
+	if err != nil {
+		return nil, err
+	}
 	requireFundingKeys := map[string]string{
 		consts.KeysIds.HubSequencer: fmt.Sprintf("Sequencer, %s Hub", raCfg.HubData.ID),
 		consts.KeysIds.HubRelayer:   fmt.Sprintf("Relayer, %s Hub", raCfg.HubData.ID),
@@ -26,5 +29,5 @@ func FormatAddresses(
 			filteredAddresses = append(filteredAddresses, address)
 		}
 	}
-	return filteredAddresses
+	return filteredAddresses, nil
 }
@@ -20,8 +20,11 @@ func setDA(rlpCfg roller.RollappConfig, value string) error {
 		return nil
 	}
 
-	if !roller.IsValidDAType(value) {
-		return fmt.Errorf("invalid DA type. Supported types are: %v", roller.SupportedDas)
+	if !roller.IsValidDAType(value) && !roller.IsDAPluginInstalled(rlpCfg.Home, value) {
+		return fmt.Errorf(
+			"invalid DA type. Supported types are: %v, or an installed DA plugin",
+			roller.SupportedDas,
+		)
 	}
 	return updateDaConfig(rlpCfg, daValue)
 }
@@ -44,7 +47,10 @@ func updateDaConfig(rlpCfg roller.RollappConfig, newDa consts.DAType) error {
 		return err
 	}
 
-	daManager := datalayer.NewDAManager(newDa, rlpCfg.Home)
+	daManager, err := datalayer.NewDAManager(newDa, rlpCfg.Home)
+	if err != nil {
+		return err
+	}
 	_, err = daManager.InitializeLightNodeConfig()
 	if err != nil {
 		return err
@@ -62,7 +68,10 @@ func updateDaConfig(rlpCfg roller.RollappConfig, newDa consts.DAType) error {
 	fmt.Printf("💈 RollApp DA has been successfully set to '%s'\n\n", newDa)
 	if newDa != consts.Local {
 		addresses := make([]keys.KeyInfo, 0)
-		damanager := datalayer.NewDAManager(newDa, rlpCfg.Home)
+		damanager, err := datalayer.NewDAManager(newDa, rlpCfg.Home)
+		if err != nil {
+			return err
+		}
 		daAddress, err := damanager.GetDAAccountAddress()
 		if err != nil {
 			return err
@@ -2,6 +2,7 @@ package consts
 
 import (
 	"fmt"
+	"path/filepath"
 )
 
 const (
@@ -78,6 +79,10 @@ var ConfigDirName = struct {
 	LocalHub             string
 	Eibc                 string
 	BlockExplorer        string
+	Snapshots            string
+	Upgrades             string
+	DAPlugins            string
+	DASwitch             string
 }{
 	Rollapp:              "rollapp",
 	Relayer:              "relayer",
@@ -87,6 +92,10 @@ var ConfigDirName = struct {
 	LocalHub:             "local-hub",
 	Eibc:                 ".eibc-client",
 	BlockExplorer:        "block-explorer",
+	Snapshots:            "snapshots",
+	Upgrades:             "upgrades",
+	DAPlugins:            filepath.Join("plugins", "da"),
+	DASwitch:             "da-switch",
 }
 
 var Denoms = struct {
@@ -29,6 +29,11 @@ type DaNetwork string
 const (
 	CelestiaTestnet DaNetwork = "mocha-4"
 	CelestiaMainnet DaNetwork = "celestia"
+	AvailTestnet    DaNetwork = "turing"
+	AvailMainnet    DaNetwork = "avail"
+	// AvailLocal is a substrate node running in development mode on the host,
+	// e.g. `avail-node --dev`
+	AvailLocal DaNetwork = "avail-local"
 )
 
 var DaNetworks = map[string]DaData{
@@ -53,7 +58,6 @@ var DaNetworks = map[string]DaData{
 		StateNodes: []string{
 			"mocha-4-consensus.mesa.newmetric.xyz",
 			"public-celestia-mocha4-consensus.numia.xyz",
-			"mocha-4-consensus.mesa.newmetric.xyz",
 			"full.consensus.mocha-4.celestia-mocha.com",
 			"consensus-full-mocha-4.celestia-mocha.com",
 			"rpc-mocha.pops.one",
@@ -71,4 +75,37 @@ var DaNetworks = map[string]DaData{
 		},
 		GasPrice: "0.002",
 	},
+	"turing": {
+		Backend:          Avail,
+		ApiUrl:           "",
+		ID:               AvailTestnet,
+		RpcUrl:           "wss://turing-rpc.avail.so/ws",
+		CurrentStateNode: "wss://turing-rpc.avail.so/ws",
+		StateNodes: []string{
+			"wss://turing-rpc.avail.so/ws",
+		},
+		GasPrice: "",
+	},
+	"avail": {
+		Backend:          Avail,
+		ApiUrl:           "",
+		ID:               AvailMainnet,
+		RpcUrl:           "wss://mainnet-rpc.avail.so/ws",
+		CurrentStateNode: "wss://mainnet-rpc.avail.so/ws",
+		StateNodes: []string{
+			"wss://mainnet-rpc.avail.so/ws",
+		},
+		GasPrice: "",
+	},
+	"avail-local": {
+		Backend:          Avail,
+		ApiUrl:           "",
+		ID:               AvailLocal,
+		RpcUrl:           "ws://127.0.0.1:9944",
+		CurrentStateNode: "ws://127.0.0.1:9944",
+		StateNodes: []string{
+			"ws://127.0.0.1:9944",
+		},
+		GasPrice: "",
+	},
 }
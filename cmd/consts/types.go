@@ -24,4 +24,9 @@ type DaData = struct {
 	CurrentStateNode string    `toml:"current_state_node"`
 	StateNodes       []string  `toml:"state_nodes"`
 	GasPrice         string    `toml:"gas_price"`
+	// NamespaceID is the celestia namespace the rollapp data is posted to,
+	// the sequencer and the full nodes have to agree on it
+	NamespaceID string `toml:"namespace_id"`
+	// AppID is the avail application the rollapp data is submitted under
+	AppID uint32 `toml:"app_id,omitempty"`
 }
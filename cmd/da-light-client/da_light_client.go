@@ -3,6 +3,9 @@ package da_light_client
 import (
 	"github.com/spf13/cobra"
 
+	da_info "github.com/dymensionxyz/roller/cmd/da-light-client/info"
+	da_reset "github.com/dymensionxyz/roller/cmd/da-light-client/reset"
+	da_resync "github.com/dymensionxyz/roller/cmd/da-light-client/resync"
 	da_start "github.com/dymensionxyz/roller/cmd/da-light-client/start"
 )
 
@@ -12,5 +15,8 @@ func DALightClientCmd() *cobra.Command {
 		Short: "Commands for running and managing the data availability light client.",
 	}
 	cmd.AddCommand(da_start.Cmd())
+	cmd.AddCommand(da_reset.Cmd())
+	cmd.AddCommand(da_resync.Cmd())
+	cmd.AddCommand(da_info.Cmd())
 	return cmd
 }
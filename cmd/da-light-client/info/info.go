@@ -0,0 +1,111 @@
+package info
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Shows the store and the sync state of the DA light client.",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.Backend != consts.Celestia {
+				pterm.Error.Printf(
+					"the DA light client only runs for %s, the rollapp uses %s\n",
+					consts.Celestia,
+					rollerData.DA.Backend,
+				)
+				return
+			}
+
+			trustedHash, sampleFrom, pruning, err := lightclient.StoreConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to read the DA light client config:", err)
+				return
+			}
+			size, err := lightclient.StoreSize(home)
+			if err != nil {
+				pterm.Error.Println("failed to compute the DA light client store size:", err)
+				return
+			}
+
+			fmt.Println("💈 Store:")
+			td := pterm.TableData{
+				{"config", lightclient.ConfigFilePath(home)},
+				{"size", fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))},
+				{"trusted hash", trustedHash},
+				{"sample from", sampleFrom},
+				{"pruning", pruning},
+			}
+			_ = pterm.DefaultTable.WithData(td).Render()
+
+			damanager := celestia.NewCelestia(home)
+			endpoint := damanager.GetLightNodeEndpoint()
+			if !lightclient.IsRunning(endpoint) {
+				pterm.Warning.Printf(
+					"the DA light client isn't running at %s, start it with %s\n",
+					endpoint,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp services start"),
+				)
+				return
+			}
+
+			token, err := damanager.GetAuthToken(consts.DaAuthTokenType.Read)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve the DA light client auth token:", err)
+				return
+			}
+
+			si, err := lightclient.GetSyncInfo(
+				&celestia.NodeClient{Endpoint: endpoint, AuthToken: token},
+			)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve the DA light client sync state:", err)
+				return
+			}
+
+			var behind uint64
+			if si.NetworkHead > si.LocalHead {
+				behind = si.NetworkHead - si.LocalHead
+			}
+
+			fmt.Println("💈 Sync:")
+			td = pterm.TableData{
+				{"local head", fmt.Sprint(si.LocalHead)},
+				{"network head", fmt.Sprint(si.NetworkHead)},
+				{"blocks behind", fmt.Sprint(behind)},
+				{"header sync", fmt.Sprintf("%d -> %d (finished: %t)", si.SyncFrom, si.SyncTo, si.SyncFinished)},
+				{"sampled head", fmt.Sprint(si.SampledHead)},
+				{"sampling catch up done", fmt.Sprint(si.CatchUpDone)},
+			}
+			_ = pterm.DefaultTable.WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package reset
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const pruningFlag = "pruning"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Removes the data of the DA light client, keeping its keys and configuration.",
+		Long: `Removes the headers, samples and blocks stored by the DA light client. The keys
+and the configuration are preserved, the light client syncs again from the
+trusted hash of its configuration once restarted. Use 'resync' to sync from a
+new trusted hash instead.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.Backend != consts.Celestia {
+				pterm.Error.Printf(
+					"the light client store can only be reset for %s, the rollapp uses %s\n",
+					consts.Celestia,
+					rollerData.DA.Backend,
+				)
+				return
+			}
+
+			damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, home)
+			if err != nil {
+				pterm.Error.Println("failed to load the DA: ", err)
+				return
+			}
+			if lightclient.IsRunning(damanager.GetLightNodeEndpoint()) {
+				pterm.Error.Printf(
+					"the DA light client is running, stop it with %s before resetting it\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp services stop"),
+				)
+				return
+			}
+
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					"the DA light client data will be removed, would you like to continue?",
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			removed, err := lightclient.ResetStore(home)
+			if err != nil {
+				pterm.Error.Println("failed to reset the DA light client:", err)
+				return
+			}
+			for _, r := range removed {
+				pterm.Info.Printf("removed %s\n", r)
+			}
+
+			if cmd.Flags().Changed(pruningFlag) {
+				pruning, _ := cmd.Flags().GetBool(pruningFlag)
+				err = lightclient.SetPruning(home, pruning)
+				if err != nil {
+					pterm.Error.Println("failed to update the pruning configuration:", err)
+					return
+				}
+			}
+
+			pterm.Success.Println("DA light client reset successfully")
+			pterm.Info.Printf(
+				"run %s to start syncing again\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp services start"),
+			)
+		},
+	}
+
+	cmd.Flags().Bool(pruningFlag, false, "enable or disable pruning of the data outside of the sampling window")
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
@@ -0,0 +1,161 @@
+package resync
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	fromFlag       = "from"
+	heightFlag     = "height"
+	hashFlag       = "hash"
+	sampleFromFlag = "sample-from"
+	pruningFlag    = "pruning"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resync",
+		Short: "Resets the DA light client and syncs it from a new trusted block.",
+		Long: fmt.Sprintf(
+			`Resets the DA light client store, keeping its keys, and sets a new trusted
+hash and height to sync from. The trusted block is either the latest celestia
+block (%s), the celestia block of the first state update of the rollapp (%s)
+or the block given with --height and --hash.`,
+			lightclient.TrustedFromLatest,
+			lightclient.TrustedFromFirstState,
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.Backend != consts.Celestia {
+				pterm.Error.Printf(
+					"the light client can only be resynced for %s, the rollapp uses %s\n",
+					consts.Celestia,
+					rollerData.DA.Backend,
+				)
+				return
+			}
+
+			damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, home)
+			if err != nil {
+				pterm.Error.Println("failed to load the DA: ", err)
+				return
+			}
+			if lightclient.IsRunning(damanager.GetLightNodeEndpoint()) {
+				pterm.Error.Printf(
+					"the DA light client is running, stop it with %s before resyncing it\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp services stop"),
+				)
+				return
+			}
+
+			from, _ := cmd.Flags().GetString(fromFlag)
+			height, _ := cmd.Flags().GetInt(heightFlag)
+			hash, _ := cmd.Flags().GetString(hashFlag)
+
+			if (height == 0) != (hash == "") {
+				pterm.Error.Printf("--%s and --%s must be set together\n", heightFlag, hashFlag)
+				return
+			}
+			if height == 0 {
+				pterm.Info.Printf("retrieving the trusted celestia block (%s)\n", from)
+				height, hash, err = lightclient.TrustedBlock(rollerData, from)
+				if err != nil {
+					pterm.Error.Println("failed to retrieve the trusted block:", err)
+					return
+				}
+			}
+
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					fmt.Sprintf(
+						"the DA light client data will be removed and synced again from height %d, would you like to continue?",
+						height,
+					),
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			err = lightclient.Resync(home, height, hash)
+			if err != nil {
+				pterm.Error.Println("failed to resync the DA light client:", err)
+				return
+			}
+
+			if cmd.Flags().Changed(sampleFromFlag) {
+				sampleFrom, _ := cmd.Flags().GetInt(sampleFromFlag)
+				err = lightclient.SetSampleFrom(home, sampleFrom)
+				if err != nil {
+					pterm.Error.Println("failed to update the sampling window:", err)
+					return
+				}
+			}
+
+			if cmd.Flags().Changed(pruningFlag) {
+				pruning, _ := cmd.Flags().GetBool(pruningFlag)
+				err = lightclient.SetPruning(home, pruning)
+				if err != nil {
+					pterm.Error.Println("failed to update the pruning configuration:", err)
+					return
+				}
+			}
+
+			pterm.Success.Printf(
+				"the DA light client will sync from height %d (%s)\n",
+				height,
+				hash,
+			)
+			pterm.Info.Printf(
+				"run %s to start syncing, follow the progress with %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp services start"),
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller da-light-client info"),
+			)
+		},
+	}
+
+	cmd.Flags().String(
+		fromFlag,
+		lightclient.TrustedFromLatest,
+		fmt.Sprintf(
+			"trusted block to sync from: %s or %s",
+			lightclient.TrustedFromLatest,
+			lightclient.TrustedFromFirstState,
+		),
+	)
+	cmd.Flags().Int(heightFlag, 0, "celestia height of the trusted block, requires --hash")
+	cmd.Flags().String(hashFlag, "", "celestia hash of the trusted block, requires --height")
+	cmd.Flags().Int(sampleFromFlag, 0, "first celestia height to sample, defaults to the trusted height")
+	cmd.Flags().Bool(pruningFlag, false, "enable or disable pruning of the data outside of the sampling window")
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
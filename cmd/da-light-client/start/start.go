@@ -16,6 +16,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/logging"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/statenodes"
 )
 
 const (
@@ -46,7 +47,8 @@ func Cmd() *cobra.Command {
 					errors.New("metrics endpoint can only be set for celestia"),
 				)
 			}
-			damanager := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+			damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+			errorhandling.PrettifyErrorIfExists(err)
 
 			if rollerData.NodeType == "sequencer" {
 				pterm.Info.Println("checking for da address balance")
@@ -59,6 +61,21 @@ func Cmd() *cobra.Command {
 				}
 			}
 
+			if rollerData.DA.Backend == consts.Celestia {
+				pterm.Info.Println("selecting the state node")
+				chosen, changed, err := statenodes.Select(rollerData, false)
+				if err != nil {
+					pterm.Warning.Printf(
+						"failed to select a state node, using %s: %v\n",
+						rollerData.DA.CurrentStateNode,
+						err,
+					)
+				} else if changed {
+					rollerData.DA.CurrentStateNode = chosen.Node
+					pterm.Info.Printf("switched the state node to %s\n", chosen.Node)
+				}
+			}
+
 			damanager.SetRPCEndpoint(rollerData.DA.CurrentStateNode)
 			if metricsEndpoint != "" {
 				damanager.SetMetricsEndpoint(metricsEndpoint)
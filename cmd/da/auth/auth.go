@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/da/auth/revoke"
+	"github.com/dymensionxyz/roller/cmd/da/auth/rotate"
+	"github.com/dymensionxyz/roller/cmd/da/auth/show"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Commands to manage the auth tokens of the DA light client",
+	}
+
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(rotate.Cmd())
+	cmd.AddCommand(revoke.Cmd())
+
+	return cmd
+}
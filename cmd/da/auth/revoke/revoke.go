@@ -0,0 +1,90 @@
+package revoke
+
+import (
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/daauth"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Invalidate every auth token issued by the DA light client",
+		Long: `Invalidate every auth token issued by the DA light client, including the one
+dymint uses. The rollapp can't reach the DA until a new token is issued with
+'roller da auth rotate'.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					"the rollapp won't be able to reach the DA until a new token is issued, would you like to continue?",
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			oldToken, err := daauth.GetDAConfigToken(home)
+			if err != nil {
+				pterm.Warning.Println("failed to read the current DA auth token:", err)
+			}
+
+			err = daauth.Revoke(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to revoke the DA auth tokens:", err)
+				return
+			}
+			pterm.Success.Println("revoked every DA auth token")
+
+			restarted, err := daauth.RestartServices(home)
+			if err != nil {
+				pterm.Error.Println("failed to restart the services:", err)
+				return
+			}
+			if oldToken != "" && slices.Contains(restarted, "da-light-client") {
+				spinner, _ := pterm.DefaultSpinner.Start(
+					"checking that the light client rejects the old token",
+				)
+				err = daauth.VerifyRevoked(home, oldToken)
+				if err != nil {
+					spinner.Fail("failed to verify the old token was revoked: " + err.Error())
+					return
+				}
+				spinner.Success("the light client rejects the old token")
+			}
+
+			pterm.Info.Println("next steps:")
+			pterm.Info.Printf(
+				"run %s to issue a new token for the rollapp\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller da auth rotate"),
+			)
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
@@ -0,0 +1,128 @@
+package rotate
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/daauth"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const permissionFlag = "permission"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Invalidate the DA auth tokens and issue a new one for dymint",
+		Long: `Invalidate every auth token issued by the DA light client and issue a new one
+for dymint. Sequencers get an admin token, full nodes a read token, unless
+--permission is set. The running light client and rollapp services are
+restarted to apply the new token.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			permission, _ := cmd.Flags().GetString(permissionFlag)
+			if permission == "" {
+				permission, err = daauth.PermissionForNodeType(rollerData.NodeType)
+				if err != nil {
+					pterm.Error.Println("failed to determine the token permission:", err)
+					return
+				}
+			}
+			if permission != consts.DaAuthTokenType.Admin &&
+				permission != consts.DaAuthTokenType.Read {
+				pterm.Error.Printf(
+					"invalid permission %s, use %s or %s\n",
+					permission,
+					consts.DaAuthTokenType.Admin,
+					consts.DaAuthTokenType.Read,
+				)
+				return
+			}
+
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					"every token issued by the DA light client will stop working, would you like to continue?",
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			oldToken, err := daauth.GetDAConfigToken(home)
+			if err != nil {
+				pterm.Warning.Println("failed to read the current DA auth token:", err)
+			}
+
+			token, err := daauth.Rotate(rollerData, permission)
+			if err != nil {
+				pterm.Error.Println("failed to rotate the DA auth token:", err)
+				return
+			}
+			pterm.Success.Printf(
+				"issued a new %s token (%s) and updated the dymint da_config\n",
+				permission,
+				daauth.Redact(token),
+			)
+
+			restarted, err := daauth.RestartServices(home)
+			if err != nil {
+				pterm.Error.Println("failed to restart the services:", err)
+				return
+			}
+			if oldToken != "" && slices.Contains(restarted, "da-light-client") {
+				spinner, _ := pterm.DefaultSpinner.Start(
+					"checking that the light client rejects the old token",
+				)
+				err = daauth.VerifyRevoked(home, oldToken)
+				if err != nil {
+					spinner.Fail("failed to verify the old token was revoked: " + err.Error())
+					return
+				}
+				spinner.Success("the light client rejects the old token")
+			}
+			if len(restarted) == 0 {
+				pterm.Info.Println("next steps:")
+				pterm.Info.Printf(
+					"run %s to apply the new token\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp services start"),
+				)
+			}
+		},
+	}
+
+	cmd.Flags().String(
+		permissionFlag,
+		"",
+		fmt.Sprintf(
+			"permission of the new token, %s or %s, defaults to the one the node type requires",
+			consts.DaAuthTokenType.Admin,
+			consts.DaAuthTokenType.Read,
+		),
+	)
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
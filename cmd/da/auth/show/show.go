@@ -0,0 +1,95 @@
+package show
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/daauth"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the auth token dymint uses to reach the DA light client",
+		Long: `Show the auth token dymint uses to reach the DA light client.
+
+The token is redacted unless --reveal is set.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			token, err := daauth.GetDAConfigToken(home)
+			if err != nil {
+				pterm.Error.Println("failed to read the dymint da_config:", err)
+				return
+			}
+			if token == "" {
+				pterm.Warning.Printf(
+					"dymint has no DA auth token, issue one with %s\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da auth rotate"),
+				)
+				return
+			}
+
+			reveal, _ := cmd.Flags().GetBool("reveal")
+			displayed := daauth.Redact(token)
+			if reveal {
+				displayed = token
+			}
+
+			permissions := "unknown"
+			allowed, err := daauth.Permissions(token)
+			if err == nil {
+				permissions = strings.Join(allowed, ", ")
+			}
+
+			expected, err := daauth.PermissionForNodeType(rollerData.NodeType)
+			if err != nil {
+				pterm.Error.Println("failed to determine the required permission:", err)
+				return
+			}
+
+			fmt.Println("💈 DA auth token:")
+			td := pterm.TableData{
+				{"token", displayed},
+				{"permissions", permissions},
+				{"node type", rollerData.NodeType},
+				{"required permission", expected},
+			}
+			_ = pterm.DefaultTable.WithData(td).Render()
+
+			if allowed != nil && !slices.Contains(allowed, expected) {
+				pterm.Warning.Printf(
+					"the token doesn't grant the %s permission, issue a new one with %s\n",
+					expected,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da auth rotate"),
+				)
+			}
+		},
+	}
+
+	cmd.Flags().Bool("reveal", false, "print the token in full")
+
+	return cmd
+}
@@ -0,0 +1,125 @@
+package costs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/dacosts"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "costs",
+		Short: "Report the DA spend and the projected runway of the DA account",
+		Long: `Report the DA spend and the projected runway of the DA account.
+
+The spend is derived from the DA balance samples the health agent records
+while the rollapp is running, every run of this command records a sample too.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output != "text" && output != "json" {
+				pterm.Error.Println("invalid output format, supported formats: text, json")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			_, err = dacosts.Record(rollerData)
+			if err != nil && output == "text" {
+				pterm.Warning.Println("failed to record the current DA balance:", err)
+			}
+
+			samples, err := dacosts.LoadSamples(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the DA balance samples:", err)
+				return
+			}
+
+			r, err := dacosts.BuildReport(samples, time.Now())
+			if err != nil {
+				pterm.Error.Println("failed to build the DA costs report:", err)
+				return
+			}
+
+			if output == "json" {
+				b, err := json.MarshalIndent(r, "", "  ")
+				if err != nil {
+					pterm.Error.Println("failed to marshal the DA costs report:", err)
+					return
+				}
+				fmt.Println(string(b))
+				return
+			}
+
+			printReport(r)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "text", "Output format (text|json)")
+
+	return cmd
+}
+
+func printReport(r *dacosts.Report) {
+	fmt.Printf("💈 DA account: %s (%s)\n", r.Address, r.DA)
+	fmt.Printf("💈 Balance: %s%s\n", r.Balance.String(), r.Denom)
+	fmt.Printf("💈 Spend, last 24h: %s%s\n", r.DailySpend.String(), r.Denom)
+	fmt.Printf("💈 Spend, last 7d: %s%s\n", r.WeeklySpend.String(), r.Denom)
+	if r.Deposits.Sign() > 0 {
+		fmt.Printf("💈 Deposits: %s%s\n", r.Deposits.String(), r.Denom)
+	}
+
+	if r.CostPerBlock != nil {
+		fmt.Printf(
+			"💈 Average cost per rollapp block: %.2f%s (%d blocks)\n",
+			*r.CostPerBlock,
+			r.Denom,
+			r.WeeklyBlocks,
+		)
+	} else {
+		fmt.Println("💈 Average cost per rollapp block: -")
+	}
+
+	if r.RunwayDays != nil {
+		fmt.Printf(
+			"💈 Runway: %.1f days at %.0f%s/day\n",
+			*r.RunwayDays,
+			*r.SpendPerDay,
+			r.Denom,
+		)
+	} else {
+		fmt.Println("💈 Runway: - (no spend recorded yet)")
+	}
+
+	fmt.Printf(
+		"💈 Based on %d samples over %s\n\n",
+		r.NumSamples,
+		r.SamplingWindow,
+	)
+
+	td := pterm.TableData{{"Date", "Spend", "Blocks"}}
+	for _, d := range r.Days {
+		td = append(td, []string{d.Date, d.Spend.String() + r.Denom, fmt.Sprint(d.Blocks)})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+}
@@ -0,0 +1,32 @@
+package da
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/da/auth"
+	"github.com/dymensionxyz/roller/cmd/da/costs"
+	"github.com/dymensionxyz/roller/cmd/da/inspect"
+	"github.com/dymensionxyz/roller/cmd/da/local"
+	"github.com/dymensionxyz/roller/cmd/da/namespace"
+	"github.com/dymensionxyz/roller/cmd/da/nodes"
+	"github.com/dymensionxyz/roller/cmd/da/plugins"
+	switchda "github.com/dymensionxyz/roller/cmd/da/switch"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "da",
+		Short: "Commands to manage the data availability layer of the rollapp",
+	}
+
+	cmd.AddCommand(auth.Cmd())
+	cmd.AddCommand(costs.Cmd())
+	cmd.AddCommand(inspect.Cmd())
+	cmd.AddCommand(local.Cmd())
+	cmd.AddCommand(namespace.Cmd())
+	cmd.AddCommand(nodes.Cmd())
+	cmd.AddCommand(plugins.Cmd())
+	cmd.AddCommand(switchda.Cmd())
+
+	return cmd
+}
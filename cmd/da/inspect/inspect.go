@@ -0,0 +1,162 @@
+package inspect
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Fetch a rollapp batch from the DA and verify it against the hub state update",
+		Long: `Fetch a rollapp batch from the DA and verify it against the hub state update.
+
+The batch of the state update with the given index, or the latest state update,
+is fetched through the DA node dymint submits to. With --da-path the batch is
+only decoded, there's no state update to verify it against.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			stateIndex, _ := cmd.Flags().GetString("state-index")
+			daPath, _ := cmd.Flags().GetString("da-path")
+			if stateIndex != "" && daPath != "" {
+				pterm.Error.Println("--state-index and --da-path are mutually exclusive")
+				return
+			}
+
+			var si *rollapp.StateInfo
+			if daPath == "" {
+				si, err = rollapp.GetStateInfo(rollerData.RollappID, stateIndex, rollerData.HubData)
+				if err != nil {
+					pterm.Error.Println("failed to retrieve the state update:", err)
+					return
+				}
+				daPath = si.DAPath
+				pterm.Info.Printf(
+					"state update %s covers blocks %s..%s\n",
+					si.StateInfoIndex.Index,
+					si.StartHeight,
+					lastHeight(si),
+				)
+			}
+
+			dp, err := celestia.ParseDAPath(daPath)
+			if err != nil {
+				pterm.Error.Println("failed to parse the DA path:", err)
+				return
+			}
+			if dp.Client != "celestia" {
+				pterm.Error.Printf("DA client %s is not supported, only celestia batches can be inspected\n", dp.Client)
+				return
+			}
+
+			client, err := celestia.NewNodeClientFromDymint(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the DA node endpoint:", err)
+				return
+			}
+
+			blob, err := client.GetBlob(dp.Height, dp.Namespace, dp.Commitment)
+			if err != nil {
+				pterm.Error.Println("failed to fetch the batch from the DA:", err)
+				return
+			}
+
+			batch, err := dymint.DecodeBatch(blob.Data)
+			if err != nil {
+				pterm.Error.Println("failed to decode the batch:", err)
+				return
+			}
+
+			fmt.Printf("💈 DA height: %d\n", dp.Height)
+			fmt.Printf("💈 Namespace: %s\n", hex.EncodeToString(dp.Namespace))
+			fmt.Printf("💈 Commitment: %s\n", hex.EncodeToString(dp.Commitment))
+			fmt.Printf("💈 Blob size: %d bytes\n", len(blob.Data))
+			fmt.Printf("💈 Blocks: %d..%d\n\n", batch.StartHeight, batch.EndHeight)
+
+			td := pterm.TableData{{"Height", "Time", "Txs", "App Hash", "Header Hash"}}
+			for _, b := range batch.Blocks {
+				td = append(
+					td, []string{
+						strconv.FormatUint(b.Height, 10),
+						b.Time.Format("2006-01-02T15:04:05Z"),
+						strconv.Itoa(b.NumTxs),
+						formatHash(b.AppHash),
+						formatHash(b.HeaderHash),
+					},
+				)
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+
+			if si == nil {
+				return
+			}
+
+			mismatches, err := dymint.VerifyBatch(batch, *si)
+			if err != nil {
+				pterm.Error.Println("failed to verify the batch:", err)
+				return
+			}
+			if len(mismatches) == 0 {
+				pterm.Success.Println("the batch matches the hub state update")
+				return
+			}
+
+			pterm.Error.Printf("the batch doesn't match the hub state update (%d mismatches):\n", len(mismatches))
+			for _, m := range mismatches {
+				fmt.Println("  -", m)
+			}
+		},
+	}
+
+	cmd.Flags().String("state-index", "", "index of the state update to inspect, defaults to the latest")
+	cmd.Flags().String("da-path", "", "DA path of the batch to inspect")
+
+	return cmd
+}
+
+func lastHeight(si *rollapp.StateInfo) string {
+	start, err := strconv.Atoi(si.StartHeight)
+	if err != nil {
+		return "?"
+	}
+	n, err := strconv.Atoi(si.NumBlocks)
+	if err != nil {
+		return "?"
+	}
+
+	return strconv.Itoa(start + n - 1)
+}
+
+func formatHash(h []byte) string {
+	if len(h) == 0 {
+		return "-"
+	}
+
+	return strings.ToUpper(hex.EncodeToString(h))
+}
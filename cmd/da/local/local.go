@@ -0,0 +1,18 @@
+package local
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/da/local/start"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local",
+		Short: "Commands to run the local DA used by the mock backend",
+	}
+
+	cmd.AddCommand(start.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package start
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/data_layer/damock"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Run the local DA serving the celestia node blob API",
+		Long: `Run the local DA serving the celestia node blob API.
+
+The blobs are persisted in the roller home, the mock backend points dymint at
+this server so a rollapp can run end to end without network access.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			cfg, err := damock.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the local DA config:", err)
+				return
+			}
+
+			addr, _ := cmd.Flags().GetString("address")
+			if addr == "" {
+				addr = cfg.Address
+			}
+
+			srv, err := damock.NewServer(home)
+			if err != nil {
+				pterm.Error.Println("failed to open the local DA store:", err)
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			pterm.Info.Printf("local DA listening on http://%s\n", addr)
+			pterm.Info.Printf("namespace id: %s\n", cfg.NamespaceID)
+			pterm.Info.Printf("data directory: %s\n", damock.LocalDADir(home))
+
+			err = srv.Serve(ctx, addr)
+			if err != nil {
+				pterm.Error.Println("local DA stopped:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().String("address", "", "address to listen on, defaults to the address in the local DA config")
+
+	return cmd
+}
@@ -0,0 +1,20 @@
+package namespace
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/da/namespace/set"
+	"github.com/dymensionxyz/roller/cmd/da/namespace/show"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "namespace",
+		Short: "Commands to manage the celestia namespace of the rollapp",
+	}
+
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(set.Cmd())
+
+	return cmd
+}
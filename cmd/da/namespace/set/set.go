@@ -0,0 +1,98 @@
+package set
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const fromHubFlag = "from-hub"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [namespace-id]",
+		Short: "Set the celestia namespace of the rollapp",
+		Long: `Set the celestia namespace of the rollapp in roller.toml and dymint.toml.
+
+The namespace id is 10 hex encoded bytes. With --from-hub it's taken from the
+latest state update of the rollapp, which is how full nodes find the namespace
+the sequencer posts to.
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.Backend != consts.Celestia {
+				pterm.Error.Printf(
+					"namespaces are only used by %s, the rollapp uses %s\n",
+					consts.Celestia,
+					rollerData.DA.Backend,
+				)
+				return
+			}
+
+			fromHub, _ := cmd.Flags().GetBool(fromHubFlag)
+			if fromHub == (len(args) == 1) {
+				pterm.Error.Println("provide either the namespace id or --from-hub")
+				return
+			}
+
+			var ns string
+			if fromHub {
+				pterm.Info.Println("retrieving the namespace of the latest state update")
+				ns, err = celestia.NamespaceIDFromHub(rollerData)
+				if err != nil {
+					pterm.Error.Println("failed to retrieve the namespace from the hub:", err)
+					return
+				}
+			} else {
+				ns = args[0]
+				hubNs, err := celestia.NamespaceIDFromHub(rollerData)
+				if err == nil && hubNs != ns {
+					pterm.Warning.Printf(
+						"the latest state update was posted to namespace %s\n",
+						hubNs,
+					)
+				}
+			}
+
+			if ns == rollerData.DA.NamespaceID {
+				pterm.Info.Printf("the rollapp already uses namespace %s\n", ns)
+			}
+
+			err = celestia.SetNamespaceID(home, ns)
+			if err != nil {
+				pterm.Error.Println("failed to set the namespace:", err)
+				return
+			}
+
+			pterm.Success.Printf("the rollapp namespace is now %s\n", ns)
+			pterm.Info.Printf(
+				"run %s to apply it\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp services restart"),
+			)
+		},
+	}
+
+	cmd.Flags().Bool(fromHubFlag, false, "use the namespace of the latest state update on the hub")
+
+	return cmd
+}
@@ -0,0 +1,166 @@
+package show
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+const blocksFlag = "blocks"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the celestia namespace of the rollapp and its recent blobs",
+		Long: `Show the celestia namespace of the rollapp and its recent blobs.
+
+The namespace of roller.toml is compared with the one dymint uses and the one
+of the latest state update on the hub. The recent blobs are listed through the
+DA light client, which has to be running.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.Backend != consts.Celestia {
+				pterm.Error.Printf(
+					"namespaces are only used by %s, the rollapp uses %s\n",
+					consts.Celestia,
+					rollerData.DA.Backend,
+				)
+				return
+			}
+
+			configured := rollerData.DA.NamespaceID
+
+			var dymintNs string
+			daCfg, err := sequencer.GetDAConfig(home)
+			if err != nil {
+				pterm.Warning.Println("failed to read the dymint da_config:", err)
+			} else {
+				dymintNs, _ = daCfg["namespace_id"].(string)
+			}
+
+			hubNs, hubErr := celestia.NamespaceIDFromHub(rollerData)
+			if hubErr != nil {
+				hubNs = "unknown: " + hubErr.Error()
+			}
+
+			fmt.Println("💈 Namespace:")
+			td := pterm.TableData{
+				{"roller.toml", valueOrNone(configured)},
+				{"dymint", valueOrNone(dymintNs)},
+				{"latest state update", hubNs},
+			}
+			_ = pterm.DefaultTable.WithData(td).Render()
+
+			if configured == "" {
+				pterm.Warning.Printf(
+					"roller.toml has no namespace, set it with %s\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da namespace set"),
+				)
+				return
+			}
+			if dymintNs != "" && dymintNs != configured {
+				pterm.Warning.Printf(
+					"dymint uses %s, run %s to point it at %s\n",
+					dymintNs,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da namespace set "+configured),
+					configured,
+				)
+			}
+			if hubErr == nil && hubNs != configured {
+				pterm.Warning.Printf(
+					"the latest state update was posted to namespace %s\n",
+					hubNs,
+				)
+			}
+
+			ns, err := celestia.NamespaceBytes(configured)
+			if err != nil {
+				pterm.Error.Println("invalid namespace:", err)
+				return
+			}
+
+			client, err := celestia.NewNodeClientFromDymint(home)
+			if err != nil {
+				pterm.Error.Println("failed to create the DA light client client:", err)
+				return
+			}
+
+			head, err := client.LocalHead()
+			if err != nil {
+				pterm.Error.Println("failed to reach the DA light client, is it running?", err)
+				return
+			}
+
+			blocks, _ := cmd.Flags().GetUint64(blocksFlag)
+			from := uint64(1)
+			if head > blocks {
+				from = head - blocks + 1
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start(
+				fmt.Sprintf("looking for blobs in celestia blocks %d to %d", from, head),
+			)
+			bd := pterm.TableData{{"Height", "Index", "Size", "Commitment"}}
+			for h := head; h >= from; h-- {
+				blobs, err := client.GetAll(h, ns)
+				if err != nil {
+					spinner.Fail(fmt.Sprintf("failed to retrieve the blobs of height %d: %s", h, err))
+					return
+				}
+				for _, b := range blobs {
+					bd = append(
+						bd, []string{
+							fmt.Sprint(h),
+							fmt.Sprint(b.Index),
+							fmt.Sprintf("%d B", len(b.Data)),
+							hex.EncodeToString(b.Commitment),
+						},
+					)
+				}
+			}
+			spinner.Success(
+				fmt.Sprintf("found %d blobs in celestia blocks %d to %d", len(bd)-1, from, head),
+			)
+
+			if len(bd) > 1 {
+				_ = pterm.DefaultTable.WithHasHeader().WithData(bd).Render()
+			}
+		},
+	}
+
+	cmd.Flags().Uint64(blocksFlag, 20, "number of recent celestia blocks to look for blobs in")
+
+	return cmd
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package add
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/statenodes"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [host]",
+		Short: "Add a custom celestia state node",
+		Long: `Add a custom celestia state node to the pool of a network.
+
+The node is given by host, its gRPC (9090) and RPC (26657) ports are used. It
+is probed and scored together with the built in state nodes.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			network, _ := cmd.Flags().GetString("network")
+			if network == "" {
+				network = string(rollerData.DA.ID)
+			}
+			if consts.DaNetworks[network].Backend != consts.Celestia {
+				pterm.Error.Printf("%s is not a celestia network\n", network)
+				return
+			}
+
+			p, err := statenodes.Load(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the state node pool:", err)
+				return
+			}
+
+			node, err := p.AddCustom(network, args[0])
+			if err != nil {
+				pterm.Error.Println("failed to add the state node:", err)
+				return
+			}
+
+			// the new node is probed right away when the rollapp uses the network
+			if network == string(rollerData.DA.ID) &&
+				rollerData.DA.Backend == consts.Celestia {
+				probes, err := p.Probe(rollerData, false)
+				if err != nil {
+					pterm.Warning.Println("failed to probe the state node:", err)
+				}
+				for _, pr := range probes {
+					if pr.Node != node {
+						continue
+					}
+					if pr.Usable() {
+						pterm.Info.Printf("%s scores %d\n", node, pr.Score)
+					} else {
+						pterm.Warning.Printf("%s isn't usable: %s\n", node, pr.Error)
+					}
+				}
+			}
+
+			err = p.Save(home)
+			if err != nil {
+				pterm.Error.Println("failed to save the state node pool:", err)
+				return
+			}
+
+			pterm.Success.Printf("added %s to the state nodes of %s\n", node, network)
+		},
+	}
+
+	cmd.Flags().String("network", "", "celestia network of the state node, defaults to the one of the rollapp")
+
+	return cmd
+}
@@ -0,0 +1,191 @@
+package nodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/da/nodes/add"
+	"github.com/dymensionxyz/roller/cmd/da/nodes/remove"
+	"github.com/dymensionxyz/roller/cmd/services/load"
+	"github.com/dymensionxyz/roller/cmd/services/restart"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/statenodes"
+)
+
+const (
+	refreshFlag = "refresh"
+	applyFlag   = "apply"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "List the celestia state nodes of the rollapp with their scores",
+		Long: `List the celestia state nodes of the rollapp with their scores.
+
+Every state node is probed for the reachability of its RPC and gRPC endpoints,
+its chain, how far it's behind the other nodes and its latency. The probes are
+cached, usable nodes are probed again after 10 minutes and failed ones after 2.
+The health agent keeps the light client on the best scoring node.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output != "text" && output != "json" {
+				pterm.Error.Println("invalid output format, supported formats: text, json")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			refresh, _ := cmd.Flags().GetBool(refreshFlag)
+			apply, _ := cmd.Flags().GetBool(applyFlag)
+
+			p, err := statenodes.Load(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the state node pool:", err)
+				return
+			}
+
+			probes, err := p.Probe(rollerData, refresh)
+			if err != nil {
+				pterm.Error.Println("failed to probe the state nodes:", err)
+				return
+			}
+
+			err = p.Save(home)
+			if err != nil {
+				pterm.Error.Println("failed to save the state node pool:", err)
+				return
+			}
+
+			if output == "json" {
+				b, err := json.MarshalIndent(probes, "", "  ")
+				if err != nil {
+					pterm.Error.Println("failed to marshal the state nodes:", err)
+					return
+				}
+				fmt.Println(string(b))
+				return
+			}
+
+			current := rollerData.DA.CurrentStateNode
+			fmt.Printf("💈 State nodes of %s:\n", rollerData.DA.ID)
+			td := pterm.TableData{
+				{"", "Node", "Score", "Latency", "Height", "Lag", "Chain ID", "Checked", "Status"},
+			}
+			for _, pr := range probes {
+				marker := ""
+				if pr.Node == current {
+					marker = "*"
+				}
+				status := "ok"
+				if !pr.Usable() {
+					status = pr.Error
+				}
+				td = append(
+					td, []string{
+						marker,
+						pr.Node,
+						fmt.Sprint(pr.Score),
+						pr.Latency.Round(time.Millisecond).String(),
+						fmt.Sprint(pr.Height),
+						fmt.Sprint(pr.Lag),
+						pr.ChainID,
+						pr.CheckedAt.Local().Format(time.TimeOnly),
+						status,
+					},
+				)
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+
+			chosen, err := statenodes.Choose(probes, current)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			if chosen.Node == current {
+				pterm.Info.Printf("the light client uses %s\n", current)
+				return
+			}
+
+			if !apply {
+				pterm.Info.Printf(
+					"%s scores better than %s, run %s to switch to it\n",
+					chosen.Node,
+					current,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da nodes --apply"),
+				)
+				return
+			}
+
+			err = statenodes.SetCurrent(home, chosen.Node)
+			if err != nil {
+				pterm.Error.Println("failed to update the state node:", err)
+				return
+			}
+			pterm.Success.Printf("the state node is now %s\n", chosen.Node)
+
+			reloadLightClient(home)
+		},
+	}
+
+	cmd.Flags().Bool(refreshFlag, false, "probe every state node again instead of using the cached probes")
+	cmd.Flags().Bool(applyFlag, false, "switch the light client to the best scoring state node")
+	cmd.Flags().StringP("output", "o", "text", "output format (text, json)")
+
+	cmd.AddCommand(add.Cmd())
+	cmd.AddCommand(remove.Cmd())
+
+	return cmd
+}
+
+func reloadLightClient(home string) {
+	services := []string{"da-light-client"}
+
+	active, err := servicemanager.IsServiceActive(services[0])
+	if err != nil || !active {
+		pterm.Info.Printf(
+			"run %s to start the light client with the new state node\n",
+			pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+				Sprint("roller da-light-client start"),
+		)
+		return
+	}
+
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		pterm.Error.Println("failed to load roller config file", err)
+		return
+	}
+
+	err = load.LoadServices(services, rollerData)
+	if err != nil {
+		pterm.Error.Println("failed to update the light client service:", err)
+		return
+	}
+
+	err = restart.RestartSystemdServices(services, home)
+	if err != nil {
+		pterm.Error.Println("failed to restart the light client:", err)
+	}
+}
@@ -0,0 +1,71 @@
+package remove
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/statenodes"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [host]",
+		Short: "Remove a custom celestia state node",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			network, _ := cmd.Flags().GetString("network")
+			if network == "" {
+				network = string(rollerData.DA.ID)
+			}
+
+			p, err := statenodes.Load(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the state node pool:", err)
+				return
+			}
+
+			node, err := p.RemoveCustom(network, args[0])
+			if err != nil {
+				pterm.Error.Println("failed to remove the state node:", err)
+				return
+			}
+
+			err = p.Save(home)
+			if err != nil {
+				pterm.Error.Println("failed to save the state node pool:", err)
+				return
+			}
+
+			pterm.Success.Printf("removed %s from the state nodes of %s\n", node, network)
+			if node == rollerData.DA.CurrentStateNode {
+				pterm.Warning.Printf(
+					"the light client still uses %s, run %s to switch to another one\n",
+					node,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da nodes --apply"),
+				)
+			}
+		},
+	}
+
+	cmd.Flags().String("network", "", "celestia network of the state node, defaults to the one of the rollapp")
+
+	return cmd
+}
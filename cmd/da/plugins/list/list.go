@@ -0,0 +1,73 @@
+package list
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/plugin"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the DA plugins installed in the roller home",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			plugins, err := plugin.List(home)
+			if err != nil {
+				pterm.Error.Println("failed to list the DA plugins:", err)
+				return
+			}
+
+			pluginsDir := filepath.Join(home, consts.ConfigDirName.DAPlugins)
+			if len(plugins) == 0 {
+				pterm.Info.Printf("no DA plugins are installed in %s\n", pluginsDir)
+				return
+			}
+
+			// roller doesn't have to be initialized to list the plugins
+			rollerData, _ := roller.LoadConfig(home)
+
+			td := pterm.TableData{
+				{"Name", "Version", "Protocol", "Network", "Path", "Status"},
+			}
+			for _, p := range plugins {
+				version, protocol, network := "-", "-", "-"
+				status := "ok"
+
+				info, err := p.Info()
+				if err != nil {
+					status = err.Error()
+				} else {
+					version = info.Version
+					protocol = strconv.Itoa(info.ProtocolVersion)
+					network = info.Network
+				}
+
+				if string(rollerData.DA.Backend) == p.Name {
+					status += " (in use)"
+				}
+
+				td = append(td, []string{p.Name, version, protocol, network, p.Path, status})
+			}
+
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package plugins
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/da/plugins/list"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Commands to manage external DA plugins",
+	}
+
+	cmd.AddCommand(list.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,359 @@
+// Package switchda implements `roller da switch`, switch is a reserved word
+package switchda
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/load"
+	"github.com/dymensionxyz/roller/cmd/services/start"
+	"github.com/dymensionxyz/roller/cmd/services/stop"
+	"github.com/dymensionxyz/roller/utils/daswitch"
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+const (
+	rollbackFlag       = "rollback"
+	safeHeightFlag     = "safe-height-timeout"
+	fundingTimeoutFlag = "funding-timeout"
+	pollingInterval    = 10 * time.Second
+)
+
+// services are stopped and started in this order, the light client has to be
+// up before the rollapp connects to it
+var services = []string{"da-light-client", "rollapp"}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "switch [network]",
+		Short: "Switch the rollapp to another DA network or backend",
+		Long: `Switch the rollapp to another DA network or backend.
+
+The target has to match the DA of the rollapp params, a target other than the
+DA of the latest state update on the hub has to be confirmed interactively.
+Sequencers are stopped right after a state update so no batch is in flight,
+the blocks produced since are submitted to the new DA. The current light node
+is kept aside and the switch can be reverted with --rollback.
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			rollback, _ := cmd.Flags().GetBool(rollbackFlag)
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+			if rollback == (len(args) == 1) {
+				pterm.Error.Println("provide either the target DA network or --rollback")
+				return
+			}
+
+			active, err := activeServices()
+			if err != nil {
+				pterm.Error.Println("failed to check the services status:", err)
+				return
+			}
+			// the node might also be running in the foreground with `roller rollapp start`
+			if !slices.Contains(active, "rollapp") {
+				if _, err := dymint.GetLocalHeight(consts.DefaultRollappRPC); err == nil {
+					pterm.Error.Println(
+						"the rollapp is running in the foreground, stop it before switching the DA",
+					)
+					return
+				}
+			}
+
+			if rollback {
+				rollbackSwitch(rollerData, active, skipConfirm)
+				return
+			}
+
+			target, err := daswitch.ResolveNetwork(home, args[0])
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			if target.Backend == rollerData.DA.Backend && target.ID == rollerData.DA.ID {
+				pterm.Info.Printf("the rollapp already uses %s\n", target.ID)
+				return
+			}
+			if target.Backend == consts.Avail {
+				target.AppID, _ = cmd.Flags().GetUint32("avail-app-id")
+				if target.AppID == 0 {
+					pterm.Error.Println("provide the avail application id of the rollapp with --avail-app-id")
+					return
+				}
+			}
+
+			pterm.Info.Println("checking the DA of the rollapp")
+			c, err := daswitch.Check(rollerData, target)
+			if c != nil {
+				hubDA := c.HubDAClient
+				if hubDA == "" {
+					hubDA = "no state update yet"
+				}
+				fmt.Println("💈 DA compatibility:")
+				td := pterm.TableData{
+					{"current DA", fmt.Sprintf("%s (%s)", rollerData.DA.Backend, rollerData.DA.ID)},
+					{"target DA", fmt.Sprintf("%s (%s)", target.Backend, target.ID)},
+					{"dymint da_layer", c.DALayer},
+					{"rollapp params DA", fmt.Sprintf("%s (%s)", c.RollappDA, c.RollappDASource)},
+					{"latest state update DA", hubDA},
+				}
+				_ = pterm.DefaultTable.WithData(td).Render()
+			}
+			if err != nil {
+				pterm.Error.Println("the rollapp can't switch to", target.ID+":", err)
+				return
+			}
+			if !c.MatchesHub() {
+				pterm.Warning.Printf(
+					"the latest state update on the hub was posted to %s, %s requires %s\n",
+					c.HubDAClient,
+					target.ID,
+					c.DALayer,
+				)
+				// --yes only skips the routine confirmation
+				if skipConfirm {
+					pterm.Error.Println(
+						"the target doesn't match the DA registered on the hub, confirm the switch interactively",
+					)
+					return
+				}
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					"the hub might reject the batches posted to the new DA, would you like to switch anyway?",
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			rb, err := daswitch.LoadRollback(home)
+			if err != nil {
+				pterm.Error.Println("failed to load the previous switch:", err)
+				return
+			}
+			if rb != nil {
+				pterm.Warning.Printf(
+					"the switch from %s can no longer be rolled back after this one, its light node stays in %s\n",
+					rb.DA.ID,
+					rb.LightNodeBackup,
+				)
+			}
+
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					fmt.Sprintf(
+						"the rollapp will be stopped and switched to %s, would you like to continue?",
+						target.ID,
+					),
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			timeout, _ := cmd.Flags().GetDuration(safeHeightFlag)
+			if rollerData.NodeType == consts.NodeType.Sequencer &&
+				slices.Contains(active, "rollapp") &&
+				rollerData.HubData.ID != consts.MockHubID &&
+				timeout > 0 {
+				spinner, _ := pterm.DefaultSpinner.Start(
+					"waiting for the next state update to stop at a safe height",
+				)
+				h, err := daswitch.WaitForStateUpdate(rollerData, pollingInterval, timeout)
+				if err != nil {
+					spinner.Fail(err.Error())
+					return
+				}
+				spinner.Success(fmt.Sprintf("the hub has the blocks up to height %d", h))
+			}
+
+			if len(active) > 0 {
+				err = stop.StopSystemdServices(active)
+				if err != nil {
+					pterm.Error.Println("failed to stop the services:", err)
+					return
+				}
+			}
+
+			previous := rollerData.DA.ID
+			pterm.Info.Printf("switching the DA to %s\n", target.ID)
+			ki, err := daswitch.Switch(rollerData, target)
+			if err != nil {
+				pterm.Error.Println("failed to switch the DA:", err)
+				pterm.Info.Printf(
+					"run %s to restore the previous DA\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller da switch --rollback"),
+				)
+				return
+			}
+			pterm.Success.Printf("the rollapp DA is now %s\n", target.ID)
+
+			if ki != nil {
+				ki.Print(keys.WithMnemonic(), keys.WithName())
+			}
+
+			rollerData, err = roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+			if ki != nil && rollerData.NodeType == consts.NodeType.Sequencer {
+				pterm.Info.Printf(
+					"fund %s to submit batches to %s, press Ctrl+C to fund it later\n",
+					ki.Address,
+					target.ID,
+				)
+				fundingTimeout, _ := cmd.Flags().GetDuration(fundingTimeoutFlag)
+				spinner, _ := pterm.DefaultSpinner.Start("waiting for the DA account to be funded")
+				err = daswitch.WaitForFunding(rollerData, pollingInterval, fundingTimeout)
+				if err != nil {
+					spinner.Warning(err.Error())
+					pterm.Warning.Printf(
+						"the rollapp can't submit batches to %s until %s is funded\n",
+						target.ID,
+						ki.Address,
+					)
+				} else {
+					spinner.Success("the DA account is funded")
+				}
+			}
+
+			restartServices(rollerData, active)
+			pterm.Info.Printf(
+				"run %s to restore %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller da switch --rollback"),
+				previous,
+			)
+		},
+	}
+
+	cmd.Flags().Bool(rollbackFlag, false, "restore the DA used before the last switch")
+	cmd.Flags().Duration(
+		safeHeightFlag,
+		15*time.Minute,
+		"how long a sequencer waits for the next state update before stopping, 0 to stop right away",
+	)
+	cmd.Flags().Duration(
+		fundingTimeoutFlag,
+		30*time.Minute,
+		"how long to wait for the new DA account to be funded, 0 to wait indefinitely",
+	)
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+	cmd.Flags().Uint32("avail-app-id", 0, "avail application id the rollapp submits its data under")
+
+	return cmd
+}
+
+func rollbackSwitch(rollerData roller.RollappConfig, active []string, skipConfirm bool) {
+	rb, err := daswitch.LoadRollback(rollerData.Home)
+	if err != nil {
+		pterm.Error.Println("failed to load the previous switch:", err)
+		return
+	}
+	if rb == nil {
+		pterm.Error.Println("there is no DA switch to roll back")
+		return
+	}
+
+	if !skipConfirm {
+		proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+			fmt.Sprintf(
+				"the rollapp will be stopped and switched back to %s, would you like to continue?",
+				rb.DA.ID,
+			),
+		)
+		if !proceed {
+			pterm.Info.Println("operation cancelled")
+			return
+		}
+	}
+
+	if len(active) > 0 {
+		err = stop.StopSystemdServices(active)
+		if err != nil {
+			pterm.Error.Println("failed to stop the services:", err)
+			return
+		}
+	}
+
+	_, err = daswitch.Restore(rollerData)
+	if err != nil {
+		pterm.Error.Println("failed to roll back the DA switch:", err)
+		return
+	}
+	pterm.Success.Printf("the rollapp DA is %s again\n", rb.DA.ID)
+
+	rollerData, err = roller.LoadConfig(rollerData.Home)
+	if err != nil {
+		pterm.Error.Println("failed to load roller config file", err)
+		return
+	}
+
+	restartServices(rollerData, active)
+}
+
+func activeServices() ([]string, error) {
+	var active []string
+	for _, s := range services {
+		ok, err := servicemanager.IsServiceActive(s)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			active = append(active, s)
+		}
+	}
+
+	return active, nil
+}
+
+// restartServices reloads the service definitions, which hold the start
+// command of the DA light client, before starting the services again
+func restartServices(rollerData roller.RollappConfig, active []string) {
+	if len(active) == 0 {
+		pterm.Info.Println("next steps:")
+		pterm.Info.Printf(
+			"run %s to start the rollapp on the new DA\n",
+			pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+				Sprint("roller rollapp services start"),
+		)
+		return
+	}
+
+	err := load.LoadServices(active, rollerData)
+	if err != nil {
+		pterm.Error.Println("failed to update the services:", err)
+		return
+	}
+
+	err = start.StartServices(active)
+	if err != nil {
+		pterm.Error.Println("failed to start the services:", err)
+	}
+}
@@ -145,8 +145,9 @@ func Cmd() *cobra.Command {
 				}
 
 				rollerTomlData := map[string]any{
-					"rollapp_id": raID,
-					"home":       home,
+					"rollapp_id":     raID,
+					"home":           home,
+					"schema_version": roller.SchemaVersion,
 
 					"HubData.id":              hd.ID,
 					"HubData.api_url":         hd.API_URL,
@@ -0,0 +1,129 @@
+package build
+
+import (
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/genesis"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build <allocation-file>",
+		Short: "Apply a token allocation to the rollapp genesis file",
+		Long: `Apply a token allocation to the rollapp genesis file.
+
+The allocation file is either YAML:
+
+  total_supply: "1000000000"
+  decimals: 18
+  allocations:
+    - address: ethm1...
+      amount: "600000000"
+    - address: ethm1...
+      amount: "300000000"
+      vesting:
+        amount: "200000000"          # defaults to the full amount
+        start: "2025-01-01T00:00:00Z" # linear vesting, omit for a cliff at the end
+        end: "2026-01-01T00:00:00Z"
+    - module: community
+      amount: "100000000"
+      permissions: []
+
+or CSV with a header row and the columns address, module, amount, vesting_amount,
+vesting_start and vesting_end, in which case --total-supply and --decimals are
+required. Amounts are in display units and converted to the base denom with the
+decimals. The allocations must add up to the total supply.
+
+The genesis balances and supply are replaced by the allocations, the checksum of
+the resulting genesis file is printed for the hub registration.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			plan, err := genesis.LoadAllocationPlan(args[0])
+			if err != nil {
+				pterm.Error.Println("failed to load the allocation file:", err)
+				return
+			}
+
+			if cmd.Flags().Changed("total-supply") {
+				plan.TotalSupply, _ = cmd.Flags().GetString("total-supply")
+			}
+			if cmd.Flags().Changed("decimals") {
+				d, _ := cmd.Flags().GetUint("decimals")
+				plan.Decimals = &d
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			err = plan.Validate(rollerData.Bech32Prefix)
+			if err != nil {
+				pterm.Error.Println("invalid allocation plan:", err)
+				return
+			}
+			pterm.Success.Printf(
+				"%d allocations add up to the total supply of %s\n",
+				len(plan.Allocations),
+				plan.TotalSupply,
+			)
+
+			if dryRun {
+				return
+			}
+
+			err = genesis.UpdateGenesisParams(home, &rollerData, plan)
+			if err != nil {
+				pterm.Error.Println("failed to update the genesis file:", err)
+				return
+			}
+
+			err = tomlconfig.UpdateFieldInFile(
+				filepath.Join(home, consts.RollerConfigFileName),
+				"decimals",
+				*plan.Decimals,
+			)
+			if err != nil {
+				pterm.Error.Println("failed to update the decimals in roller.toml:", err)
+				return
+			}
+
+			s, err := genesis.Summarize(genesis.GetGenesisFilePath(home))
+			if err != nil {
+				pterm.Error.Println("failed to read the genesis file:", err)
+				return
+			}
+
+			pterm.Success.Println("genesis file updated")
+			pterm.Info.Printf("genesis file: %s\n", s.Path)
+			pterm.Info.Printf("genesis checksum: %s\n", s.Checksum)
+			pterm.Info.Println(
+				"use the checksum when registering the rollapp genesis on the hub",
+			)
+		},
+	}
+
+	cmd.Flags().String("total-supply", "", "total supply in display units, overrides the allocation file")
+	cmd.Flags().Uint("decimals", 0, "token decimals, overrides the allocation file")
+	cmd.Flags().Bool("dry-run", false, "only validate the allocation file")
+
+	return cmd
+}
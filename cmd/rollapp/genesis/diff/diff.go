@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/genesis"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <genesis-file> [genesis-file]",
+		Short: "Show the differences between two genesis files",
+		Long: `Show the semantic differences between two genesis files, key ordering and
+formatting are ignored. When a single file is provided, it's compared with the
+genesis of the local rollapp.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			left := genesis.GetGenesisFilePath(home)
+			right := args[0]
+			if len(args) == 2 {
+				left, right = args[0], args[1]
+			}
+
+			diffs, err := genesis.Diff(left, right)
+			if err != nil {
+				pterm.Error.Println("failed to compare the genesis files:", err)
+				return
+			}
+
+			if len(diffs) == 0 {
+				pterm.Success.Println("the genesis files are equivalent")
+				return
+			}
+
+			td := pterm.TableData{{"Field", left, right}}
+			for _, d := range diffs {
+				td = append(td, []string{d.Field, pterm.Red(d.Left), pterm.Green(d.Right)})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+
+			pterm.Info.Printf("%d fields differ\n", len(diffs))
+		},
+	}
+
+	return cmd
+}
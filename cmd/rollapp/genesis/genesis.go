@@ -0,0 +1,24 @@
+package genesis
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/rollapp/genesis/build"
+	"github.com/dymensionxyz/roller/cmd/rollapp/genesis/diff"
+	"github.com/dymensionxyz/roller/cmd/rollapp/genesis/show"
+	"github.com/dymensionxyz/roller/cmd/rollapp/genesis/verify"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "genesis [command]",
+		Short: "Commands to build, inspect, verify and compare rollapp genesis files",
+	}
+
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(verify.Cmd())
+	cmd.AddCommand(diff.Cmd())
+	cmd.AddCommand(build.Cmd())
+
+	return cmd
+}
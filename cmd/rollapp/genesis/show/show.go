@@ -0,0 +1,88 @@
+package show
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/genesis"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [genesis-file]",
+		Short: "Show a summary of the rollapp genesis file",
+		Long: `Show a summary of the rollapp genesis file: chain id, accounts, supply, denom
+metadata and rollapp params. The genesis of the local rollapp is used by default.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			path := genesis.GetGenesisFilePath(home)
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			s, err := genesis.Summarize(path)
+			if err != nil {
+				pterm.Error.Println("failed to read the genesis file:", err)
+				return
+			}
+
+			pterm.DefaultSection.WithIndentCharacter("💈").Println("Genesis")
+			_ = pterm.DefaultTable.WithData(
+				pterm.TableData{
+					{"file", s.Path},
+					{"checksum", s.Checksum},
+					{"chain id", s.ChainID},
+					{"genesis time", s.GenesisTime.Format(time.RFC3339)},
+					{"initial height", strconv.FormatInt(s.InitialHeight, 10)},
+					{"accounts", strconv.Itoa(s.Accounts)},
+					{"balances", strconv.Itoa(s.Balances)},
+					{"rollapp version", s.Version},
+					{"da", s.DA},
+				},
+			).Render()
+
+			pterm.DefaultSection.WithIndentCharacter("💈").Println("Supply")
+			if len(s.Supply) == 0 {
+				pterm.Info.Println("no supply in the genesis file")
+			} else {
+				td := pterm.TableData{{"Denom", "Amount"}}
+				for _, c := range s.Supply {
+					td = append(td, []string{c.Denom, c.Amount})
+				}
+				_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+			}
+
+			pterm.DefaultSection.WithIndentCharacter("💈").Println("Denom metadata")
+			if len(s.DenomMetadata) == 0 {
+				pterm.Warning.Println("no denom metadata in the genesis file")
+				return
+			}
+
+			td := pterm.TableData{{"Base", "Display", "Symbol", "Units"}}
+			for _, m := range s.DenomMetadata {
+				var units []string
+				for _, u := range m.DenomUnits {
+					units = append(units, u.Denom+"^"+strconv.FormatUint(uint64(u.Exponent), 10))
+				}
+				td = append(td, []string{m.Base, m.Display, m.Symbol, strings.Join(units, ", ")})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package verify
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/genesis"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [genesis-file]",
+		Short: "Verify the rollapp genesis file against the hub",
+		Long: `Verify the checksum and the chain id of the rollapp genesis file.
+
+The checksum is compared with the one registered for the rollapp on the hub. Use
+--offline together with --checksum to verify against a known checksum without
+querying the hub. The genesis of the local rollapp is used by default.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			offline, _ := cmd.Flags().GetBool("offline")
+			checksum, _ := cmd.Flags().GetString("checksum")
+			raID, _ := cmd.Flags().GetString("chain-id")
+
+			path := genesis.GetGenesisFilePath(home)
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			if offline && checksum == "" {
+				pterm.Error.Println("--checksum is required when verifying offline")
+				return
+			}
+
+			var rollerData roller.RollappConfig
+			if raID == "" || !offline {
+				rollerData, err = roller.LoadConfig(home)
+				if err != nil {
+					pterm.Error.Println("failed to load roller config file", err)
+					return
+				}
+			}
+			if raID == "" {
+				raID = rollerData.RollappID
+			}
+
+			ok := true
+			err = genesis.VerifyGenesisChainID(path, raID)
+			if err != nil {
+				pterm.Error.Println(err)
+				ok = false
+			} else {
+				pterm.Success.Printf("chain id matches %s\n", raID)
+			}
+
+			if checksum == "" {
+				checksum, err = genesis.GetRollappGenesisChecksum(raID, rollerData.HubData)
+				if err != nil {
+					pterm.Error.Println("failed to retrieve the genesis checksum from the hub:", err)
+					return
+				}
+			}
+
+			err = genesis.VerifyChecksum(path, checksum)
+			if err != nil {
+				pterm.Error.Println(err)
+				ok = false
+			} else {
+				pterm.Success.Printf("checksum matches %s\n", checksum)
+			}
+
+			if !ok {
+				pterm.Error.Println("genesis verification failed")
+				return
+			}
+			pterm.Success.Println("genesis file verified")
+		},
+	}
+
+	cmd.Flags().Bool("offline", false, "don't query the hub, requires --checksum")
+	cmd.Flags().String("checksum", "", "expected sha-256 checksum of the genesis file")
+	cmd.Flags().String("chain-id", "", "expected chain id, the rollapp id from roller.toml by default")
+
+	return cmd
+}
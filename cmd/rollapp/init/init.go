@@ -2,7 +2,6 @@ package initrollapp
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -14,10 +13,10 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/dependencies"
-	"github.com/dymensionxyz/roller/utils/dependencies/types"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/upgrades"
 	"github.com/dymensionxyz/roller/version"
 )
 
@@ -57,6 +56,15 @@ func Cmd() *cobra.Command {
 				return
 			}
 
+			// binaries pinned in a previous initialization
+			var pins map[string]string
+			if !isFirstInitialization {
+				rollerData, err := roller.LoadConfig(home)
+				if err == nil {
+					pins = rollerData.Binaries
+				}
+			}
+
 			var hd consts.HubData
 			var env string
 			var raID string
@@ -76,23 +84,13 @@ func Cmd() *cobra.Command {
 			// TODO: move to consts
 			// TODO(v2):  move to roller config
 			if !shouldUseMockBackend && env != "custom" {
-				dymdBinaryOptions := types.Dependency{
-					DependencyName:  "dymension",
-					RepositoryOwner: "dymensionxyz",
-					RepositoryName:  "dymension",
-					RepositoryUrl:   "https://github.com/artemijspavlovs/dymension",
-					Release:         "v3.1.0-pg07",
-					Binaries: []types.BinaryPathPair{
-						{
-							Binary:            "dymd",
-							BinaryDestination: consts.Executables.Dymension,
-							BuildCommand:      exec.Command("make", "build"),
-						},
-					},
-					PersistFiles: []types.PersistFile{},
-				}
+				dymdBinaryOptions := dependencies.DymdDependency(pins)
 				pterm.Info.Println("installing dependencies")
-				err = dependencies.InstallBinaryFromRelease(dymdBinaryOptions)
+				if dependencies.IsInstalled(dymdBinaryOptions) {
+					pterm.Info.Printf("[dymension] %s is already installed\n", dymdBinaryOptions.Release)
+				} else {
+					err = dependencies.InstallBinaryFromRelease(dymdBinaryOptions)
+				}
 				if err != nil {
 					pterm.Error.Println("failed to install dymd: ", err)
 					return
@@ -139,7 +137,12 @@ func Cmd() *cobra.Command {
 					},
 				}
 
-				_, _, err = dependencies.InstallBinaries(true, raRespMock)
+				_, _, err = dependencies.InstallBinaries(
+					true,
+					raRespMock,
+					pins,
+					upgrades.DependencyStager(home),
+				)
 				if err != nil {
 					pterm.Error.Println("failed to install binaries: ", err)
 					return
@@ -177,7 +180,12 @@ func Cmd() *cobra.Command {
 			}
 
 			start := time.Now()
-			builtDeps, _, err := dependencies.InstallBinaries(false, *raResponse)
+			builtDeps, _, err := dependencies.InstallBinaries(
+				false,
+				*raResponse,
+				pins,
+				upgrades.DependencyStager(home),
+			)
 			if err != nil {
 				pterm.Error.Println("failed to install binaries: ", err)
 				return
@@ -207,6 +215,17 @@ func Cmd() *cobra.Command {
 						return
 					}
 				}
+
+				// the genesis file pins the rollapp to a commit, the release
+				// it belongs to is the baseline the migrations are ordered from
+				err = upgrades.SetBaseline(
+					home,
+					upgrades.InstalledVersion(home, builtDeps["rollapp"].Release),
+				)
+				if err != nil {
+					pterm.Error.Println("failed to record the rollapp release: ", err)
+					return
+				}
 			}
 
 			bp, err := rollapp.ExtractBech32PrefixFromBinary(
@@ -244,6 +263,7 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.Flags().Bool("mock", false, "initialize the rollapp with mock backend")
+	cmd.Flags().Uint32("avail-app-id", 0, "avail application id the rollapp submits its data under")
 
 	return cmd
 }
@@ -1,8 +1,10 @@
 package initrollapp
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pterm/pterm"
@@ -10,6 +12,7 @@ import (
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
 	celestialightclient "github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
@@ -142,15 +145,30 @@ func runInit(
 	var daNetwork string
 	switch env {
 	case "playground":
-		if daBackend == string(consts.Celestia) {
+		switch daBackend {
+		case string(consts.Celestia):
 			daNetwork = string(consts.CelestiaTestnet)
-		} else {
+		case string(consts.Avail):
+			daNetwork = string(consts.AvailTestnet)
+		default:
 			return fmt.Errorf("unsupported DA backend: %s", daBackend)
 		}
 	case "custom":
-		if daBackend == string(consts.Celestia) {
+		switch daBackend {
+		case string(consts.Celestia):
 			daNetwork = string(consts.CelestiaTestnet)
-		} else {
+		case string(consts.Avail):
+			daNetwork, _ = pterm.DefaultInteractiveSelect.
+				WithDefaultText("select the avail network").
+				WithOptions(
+					[]string{
+						string(consts.AvailTestnet),
+						string(consts.AvailMainnet),
+						string(consts.AvailLocal),
+					},
+				).
+				Show()
+		default:
 			return fmt.Errorf("unsupported DA backend: %s", daBackend)
 		}
 	case "mock":
@@ -160,11 +178,19 @@ func runInit(
 	}
 
 	daData = consts.DaNetworks[daNetwork]
+	if daData.Backend == consts.Avail {
+		daData.AppID, err = getAvailAppID(cmd)
+		if err != nil {
+			return err
+		}
+	}
+	initConfig.DA = daData
 	rollerTomlData := map[string]any{
 		"rollapp_id":      raID,
 		"rollapp_binary":  strings.ToLower(consts.Executables.RollappEVM),
 		"rollapp_vm_type": string(initConfigPtr.RollappVMType),
 		"home":            home,
+		"schema_version":  roller.SchemaVersion,
 
 		"HubData.id":              hd.ID,
 		"HubData.api_url":         hd.API_URL,
@@ -180,6 +206,9 @@ func runInit(
 		"DA.state_nodes":        daData.StateNodes,
 		"DA.gas_price":          daData.GasPrice,
 	}
+	if daData.Backend == consts.Avail {
+		rollerTomlData["DA.app_id"] = int64(daData.AppID)
+	}
 
 	for key, value := range rollerTomlData {
 		err = tomlconfig.UpdateFieldInFile(
@@ -210,7 +239,12 @@ func runInit(
 	raSpinner.Success("rollapp initialized successfully")
 
 	/* ------------------------ Initialize DA light node ------------------------ */
-	daKeyInfo, err := celestialightclient.Initialize(env, initConfig)
+	var daKeyInfo *keys.KeyInfo
+	if initConfig.DA.Backend == consts.Avail {
+		daKeyInfo, err = initializeAvailAccount(initConfig)
+	} else {
+		daKeyInfo, err = celestialightclient.Initialize(env, initConfig)
+	}
 	if err != nil {
 		return err
 	}
@@ -226,6 +260,48 @@ func runInit(
 	return nil
 }
 
+// getAvailAppID returns the avail application id from the flag, or asks for
+// it. The application has to be created on avail beforehand
+func getAvailAppID(cmd *cobra.Command) (uint32, error) {
+	v := cmd.Flag("avail-app-id").Value.String()
+	if !cmd.Flags().Changed("avail-app-id") {
+		v, _ = pterm.DefaultInteractiveTextInput.WithDefaultText(
+			"provide the avail application id the rollapp submits its data under",
+		).Show()
+	}
+
+	appID, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid avail app id %q: %w", v, err)
+	}
+	if appID == 0 {
+		return 0, errors.New("avail app id 0 is reserved, create an application key for the rollapp")
+	}
+
+	return uint32(appID), nil
+}
+
+// initializeAvailAccount generates the account dymint submits the batches to
+// avail with, avail doesn't require a light client
+func initializeAvailAccount(rollerData roller.RollappConfig) (*keys.KeyInfo, error) {
+	damanager, err := datalayer.NewDAManager(consts.Avail, rollerData.Home)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := damanager.InitializeLightNodeConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ki, err := damanager.GetDAAccountAddress()
+	if err != nil {
+		return nil, err
+	}
+	ki.Mnemonic = mnemonic
+
+	return ki, nil
+}
+
 func PrintInitOutput(
 	rollappConfig roller.RollappConfig,
 	addresses []keys.KeyInfo,
@@ -245,7 +321,11 @@ func PrintInitOutput(
 	if rollappConfig.HubData.ID != consts.MockHubID {
 		pterm.DefaultSection.WithIndentCharacter("🔔").
 			Println("Please fund the addresses below to register and run the rollapp.")
-		fa := initconfig.FormatAddresses(rollappConfig, addresses)
+		fa, err := initconfig.FormatAddresses(rollappConfig, addresses)
+		if err != nil {
+			pterm.Error.Println("failed to format the addresses: ", err)
+			return
+		}
 		for _, v := range fa {
 			v.Print(keys.WithName())
 		}
@@ -1,20 +1,14 @@
 package migrate
 
 import (
-	"errors"
-	"fmt"
 	"strings"
-	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
-	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	"github.com/dymensionxyz/roller/utils/migrations"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/upgrades"
 )
@@ -23,6 +17,12 @@ func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Migrates the roller configuration to the newly installed version.",
+		Long: `Migrates the roller configuration to the newly installed version.
+
+Every config migration has a stable id and is recorded in ~/.roller/migrations.json
+together with the changes it made, so it's applied only once and can be reverted with
+--revert. Migrations are ordered by the version index bundled with roller, no network
+access is required. Use --dry-run to show the exact changes without applying them.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			home, err := filesystem.ExpandHomePath(
 				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
@@ -35,181 +35,81 @@ func Cmd() *cobra.Command {
 			rollerData, err := roller.LoadConfig(home)
 			errorhandling.PrettifyErrorIfExists(err)
 
-			raUpgrade, err := upgrades.NewRollappUpgrade(string(rollerData.RollappVMType))
-			if err != nil {
-				pterm.Error.Println("failed to check rollapp version equality: ", err)
-				return
-			}
-			pterm.Info.Printf(
-				"starting migration process from %s to %s\n",
-				rollerData.RollappBinaryVersion,
-				raUpgrade.CurrentVersionCommit,
-			)
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			revert, _ := cmd.Flags().GetString("revert")
+
+			if revert != "" {
+				var entries []upgrades.LedgerEntry
+				if strings.Contains(revert, "/") {
+					e, err := upgrades.RevertMigration(home, revert, dryRun)
+					if err != nil {
+						pterm.Error.Println("failed to revert the migration:", err)
+						return
+					}
+					entries = append(entries, *e)
+				} else {
+					entries, err = upgrades.RevertVersion(home, revert, dryRun)
+					if err != nil {
+						pterm.Error.Println("failed to revert the migrations:", err)
+						return
+					}
+				}
 
-			switch rollerData.RollappVMType {
-			case consts.EVM_ROLLAPP:
-				rollappType := "rollapp-evm"
-				err = applyMigrations(
-					rollerData.RollappBinaryVersion, raUpgrade.CurrentVersionCommit, rollappType,
-					upgrades.EvmRollappUpgradeModules,
-				)
-				if err != nil {
-					pterm.Error.Println("failed to apply migrations: ", err)
+				if len(entries) == 0 {
+					pterm.Info.Printf("no applied migrations found for %s\n", revert)
 					return
 				}
-			case consts.WASM_ROLLAPP:
-				rollappType := "rollapp-wasm"
-				err = applyMigrations(
-					rollerData.RollappBinaryVersion, raUpgrade.CurrentVersionCommit, rollappType,
-					upgrades.WasmRollappUpgradeModules,
-				)
-				if err != nil {
-					pterm.Error.Println("failed to apply migrations: ", err)
-					return
+				upgrades.PrintEntries(entries)
+				if !dryRun {
+					pterm.Success.Printf("%d migrations reverted\n", len(entries))
 				}
-			default:
-				pterm.Error.Println("unsupported rollapp VM type")
 				return
 			}
-		},
-	}
-	return cmd
-}
 
-func applyMigrations(from, to, vmt string, versions []upgrades.Version) error {
-	var fromTs time.Time
-	var toTs time.Time
-	var err error
-
-	if strings.HasPrefix(from, "v") {
-		fromTs, err = migrations.GetCommitTimestampByTag(
-			"dymensionxyz",
-			vmt,
-			from,
-		)
-	} else {
-		fromTs, err = migrations.GetCommitTimestamp(
-			"dymensionxyz",
-			vmt,
-			from,
-		)
-	}
-	if err != nil {
-		return err
-	}
-
-	if strings.HasPrefix(from, "v") {
-		toTs, err = migrations.GetCommitTimestampByTag(
-			"dymensionxyz",
-			vmt,
-			to,
-		)
-	} else {
-		toTs, err = migrations.GetCommitTimestamp(
-			"dymensionxyz",
-			vmt,
-			to,
-		)
-	}
-	if err != nil {
-		return err
-	}
-
-	if fromTs == toTs || toTs.Before(fromTs) {
-		return errors.New("the commit to migrate to must be younger then current commit")
-	}
-
-	var versionsToApply []upgrades.Version
-
-	// get the commit timestamp of the version and compare it with the timestamp of the
-	// from and to ts, if it's older than from ts, ignore it, if it's younger than to ts
-	// ignore it, otherwise add it to the versions to apply
-	pterm.Info.Println("iterating through all available upgrades")
-	for _, v := range versions {
-		var upgradeVersionTimestamp time.Time
-
-		if strings.HasPrefix(from, "v") {
-			upgradeVersionTimestamp, err = migrations.GetCommitTimestampByTag(
-				"dymensionxyz",
-				vmt,
-				v.VersionIdentifier,
-			)
-			if err != nil {
-				return err
+			if from == "" {
+				from = rollerData.RollappBinaryVersion
 			}
-		} else {
-			upgradeVersionTimestamp, err = migrations.GetCommitTimestamp(
-				"dymensionxyz",
-				vmt,
-				v.VersionIdentifier,
-			)
-			if err != nil {
-				return err
+			// the installed version is read from the roller records so no
+			// network access is needed
+			if to == "" {
+				to = upgrades.InstalledVersion(home, rollerData.RollappBinaryVersion)
 			}
-		}
-
-		fmt.Println("version: ", upgradeVersionTimestamp, v.VersionIdentifier)
-		fmt.Println("from: ", fromTs, from)
-		fmt.Println("to: ", fromTs, to)
-
-		isNew := upgradeVersionTimestamp.Before(toTs) || upgradeVersionTimestamp.Equal(toTs)
-		if upgradeVersionTimestamp.After(fromTs) || isNew {
-			pterm.Info.Printf("adding %s to the relevant upgrades\n", v.VersionIdentifier)
-			versionsToApply = append(versionsToApply, v)
-		}
-	}
 
-	pterm.Info.Println("applying relevant upgrades")
-	for _, version := range versionsToApply {
-		pterm.Info.Printf("applying %s config changes\n", version.VersionIdentifier)
-		err := applyMigration(version)
-		if err != nil {
-			pterm.Error.Printf(
-				"failed to apply %s config changes: %v\n",
-				version.VersionIdentifier,
-				err,
+			pterm.Info.Printf("starting migration process from %s to %s\n", from, to)
+			entries, err := upgrades.Migrate(
+				home,
+				string(rollerData.RollappVMType),
+				from,
+				to,
+				dryRun,
 			)
-			return err
-		}
-	}
-	return nil
-}
-
-func applyMigration(v upgrades.Version) error {
-	// nested loops, yuck
-	for _, module := range v.Modules {
-		if len(module.Values.NewValues) != 0 {
-			for _, nw := range module.Values.NewValues {
-				err := tomlconfig.UpdateFieldInFile(module.ConfigFilePath, nw.Path, nw.Value)
-				if err != nil {
-					return err
-				}
+			upgrades.PrintEntries(entries)
+			if err != nil {
+				pterm.Error.Println("failed to apply migrations: ", err)
+				return
 			}
-		}
 
-		if len(module.Values.DeprecatedValues) != 0 {
-			for _, dw := range module.Values.DeprecatedValues {
-				err := tomlconfig.RemoveFieldFromFile(module.ConfigFilePath, dw)
-				if err != nil {
-					return err
-				}
+			if dryRun {
+				pterm.Info.Printf("%d migrations would be applied\n", len(entries))
+				return
 			}
-		}
-
-		if len(module.Values.UpgradeableValues) != 0 {
-			for _, uw := range module.Values.UpgradeableValues {
-				err := tomlconfig.ReplaceFieldInFile(
-					module.ConfigFilePath,
-					uw.OldValuePath,
-					uw.NewValuePath,
-					uw.Value,
-				)
-				if err != nil {
-					return err
-				}
+
+			err = upgrades.RecordBinaryVersion(home, string(rollerData.RollappVMType))
+			if err != nil {
+				pterm.Error.Println("failed to update the rollapp version in roller.toml: ", err)
+				return
 			}
-		}
+
+			pterm.Success.Printf("%d migrations applied\n", len(entries))
+		},
 	}
 
-	return nil
+	cmd.Flags().Bool("dry-run", false, "show the changes without applying them")
+	cmd.Flags().String("from", "", "version the configuration was created for, used for the first migration")
+	cmd.Flags().String("to", "", "version to migrate to, the installed rollapp version by default")
+	cmd.Flags().String("revert", "", "migration id or version to revert")
+
+	return cmd
 }
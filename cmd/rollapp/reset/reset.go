@@ -0,0 +1,112 @@
+package reset
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Reset the rollapp node data",
+		Long: `Reset the rollapp node data to recover from a corrupted state.
+
+Modes:
+  full           remove all node data and the address book, the node resyncs from scratch
+  keep-addrbook  remove all node data but keep the address book
+
+Everything that is removed is moved to ~/.roller/rollapp/data.reset-<timestamp> first.
+The validator state (priv_validator_state.json), keys, genesis and configuration files
+are always preserved. The rollapp must be stopped before resetting it.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			mode, _ := cmd.Flags().GetString("mode")
+			skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+			if !slices.Contains(rollapp.ResetModes, mode) {
+				pterm.Error.Printf(
+					"invalid mode %s, use one of: %s\n",
+					mode,
+					strings.Join(rollapp.ResetModes, ", "),
+				)
+				return
+			}
+
+			active, err := servicemanager.IsServiceActive("rollapp")
+			if err != nil {
+				pterm.Error.Println("failed to check the rollapp service status:", err)
+				return
+			}
+			// the node might also be running in the foreground with `roller rollapp start`
+			if _, err := dymint.GetLocalHeight("http://localhost:26657"); err == nil {
+				active = true
+			}
+			if active {
+				pterm.Error.Printf(
+					"the rollapp is running, stop it with %s before resetting it\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller rollapp services stop"),
+				)
+				return
+			}
+
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show(
+					"the rollapp node data will be reset (" + mode + "), would you like to continue?",
+				)
+				if !proceed {
+					pterm.Info.Println("operation cancelled")
+					return
+				}
+			}
+
+			res, err := rollapp.Reset(home, mode)
+			if err != nil {
+				pterm.Error.Println("failed to reset the rollapp:", err)
+				if res != nil {
+					pterm.Info.Printf("the removed data was moved to %s\n", res.BackupDir)
+				}
+				return
+			}
+
+			for _, r := range res.Removed {
+				pterm.Info.Printf("removed %s\n", r)
+			}
+			pterm.Success.Println("rollapp reset successfully")
+			pterm.Info.Printf(
+				"the removed data and the validator state were backed up to %s\n",
+				res.BackupDir,
+			)
+
+			pterm.Info.Println("next steps:")
+			pterm.Info.Printf(
+				"run %s to start the rollapp, or restore a snapshot first with %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp services start"),
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp snapshot restore"),
+			)
+		},
+	}
+
+	cmd.Flags().String("mode", rollapp.ResetModeFull, "reset mode: full or keep-addrbook")
+	cmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
@@ -4,13 +4,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dymensionxyz/roller/cmd/rollapp/config"
+	"github.com/dymensionxyz/roller/cmd/rollapp/genesis"
 	initrollapp "github.com/dymensionxyz/roller/cmd/rollapp/init"
 	"github.com/dymensionxyz/roller/cmd/rollapp/keys"
 	"github.com/dymensionxyz/roller/cmd/rollapp/migrate"
+	"github.com/dymensionxyz/roller/cmd/rollapp/reset"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer"
 	"github.com/dymensionxyz/roller/cmd/rollapp/setup"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot"
+	"github.com/dymensionxyz/roller/cmd/rollapp/standby"
 	"github.com/dymensionxyz/roller/cmd/rollapp/start"
 	"github.com/dymensionxyz/roller/cmd/rollapp/status"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade"
 	"github.com/dymensionxyz/roller/cmd/services"
 	loadservices "github.com/dymensionxyz/roller/cmd/services/load"
 	logservices "github.com/dymensionxyz/roller/cmd/services/logs"
@@ -33,6 +38,11 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(sequencer.Cmd())
 	cmd.AddCommand(keys.Cmd())
 	cmd.AddCommand(migrate.Cmd())
+	cmd.AddCommand(standby.Cmd())
+	cmd.AddCommand(snapshot.Cmd())
+	cmd.AddCommand(reset.Cmd())
+	cmd.AddCommand(genesis.Cmd())
+	cmd.AddCommand(upgrade.Cmd())
 
 	sl := []string{"rollapp", "da-light-client"}
 	cmd.AddCommand(
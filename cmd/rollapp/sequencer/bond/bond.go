@@ -6,6 +6,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond/decrease"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond/get"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond/increase"
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond/policy"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond/unbond"
 )
 
@@ -19,6 +20,7 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(increase.Cmd())
 	cmd.AddCommand(decrease.Cmd())
 	cmd.AddCommand(unbond.Cmd())
+	cmd.AddCommand(policy.Cmd())
 
 	return cmd
 }
@@ -1,18 +1,15 @@
 package decrease
 
 import (
-	"fmt"
-	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
-	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -44,15 +41,19 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			c := exec.Command(
-				consts.Executables.Dymension, "tx",
-				"sequencer", "decrease-bond", amount, "--keyring-backend",
-				"test", "--from", consts.KeysIds.HubSequencer, "--keyring-dir", filepath.Join(
-					home,
-					consts.ConfigDirName.HubKeys,
-				), "--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
-				"--node", rollerData.HubData.RPC_URL, "--chain-id", rollerData.HubData.ID,
-			)
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if dryRun {
+				s, err := sequencer.SimulateBondTx(rollerData, sequencer.BondActionDecrease, amount)
+				if err != nil {
+					pterm.Error.Println("failed to simulate bond transaction: ", err)
+					return
+				}
+
+				s.Print()
+				return
+			}
+
+			c := sequencer.GetBondTxCmd(rollerData, sequencer.BondActionDecrease, amount)
 
 			txOutput, err := bash.ExecCommandWithInput(c, "signatures")
 			if err != nil {
@@ -74,5 +75,7 @@ func Cmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("dry-run", false, "simulate the transaction without broadcasting it")
+
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/sequencer"
 )
@@ -39,8 +40,43 @@ func Cmd() *cobra.Command {
 			pterm.DefaultSection.WithIndentCharacter("💈").
 				Printf("%s bonded tokens", address)
 			fmt.Println(bond.String())
+
+			if !rollerData.BondPolicy.Enabled {
+				return
+			}
+
+			status, err := sequencer.GetBondPolicyStatus(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to evaluate bond policy", err)
+				return
+			}
+
+			pterm.DefaultSection.WithIndentCharacter("💈").
+				Println("bond policy")
+			fmt.Printf("minimum bond: %s%s\n", status.MinBond.String(), consts.Denoms.Hub)
+			fmt.Printf("required bond: %s%s\n", status.RequiredBond.String(), consts.Denoms.Hub)
+			fmt.Printf("deficit: %s%s\n", status.Deficit.String(), consts.Denoms.Hub)
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun || !status.Deficit.IsPositive() {
+				return
+			}
+
+			s, err := sequencer.SimulateBondTx(
+				rollerData,
+				sequencer.BondActionIncrease,
+				status.Deficit.String()+consts.Denoms.Hub,
+			)
+			if err != nil {
+				pterm.Error.Println("failed to simulate bond top-up", err)
+				return
+			}
+			s.Print()
 		},
 	}
 
+	cmd.Flags().
+		Bool("dry-run", false, "simulate the bond top-up that the bond policy would perform")
+
 	return cmd
 }
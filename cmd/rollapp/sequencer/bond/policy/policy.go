@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "policy",
+		Example: "roller rollapp sequencer bond policy --enabled " +
+			"--target-bond 100000000000000000000adym --min-buffer 10000000000000000000adym",
+		Short: "Configure the bond policy enforced by the health agent",
+		Long: `Configure the bond policy enforced by the health agent.
+
+When enabled, the health agent keeps the sequencer bond at or above the higher of
+the target bond and the hub minimum bond increased by the minimum buffer,
+topping it up from the sequencer account when needed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerConfigPath := roller.GetConfigPath(home)
+			updates := map[string]string{
+				"enabled":     "bond_policy.enabled",
+				"target-bond": "bond_policy.target_bond",
+				"min-buffer":  "bond_policy.min_buffer",
+			}
+
+			for flag, key := range updates {
+				if !cmd.Flags().Changed(flag) {
+					continue
+				}
+
+				var v any
+				if flag == "enabled" {
+					v, _ = cmd.Flags().GetBool(flag)
+				} else {
+					v, _ = cmd.Flags().GetString(flag)
+				}
+
+				err = tomlconfig.UpdateFieldInFile(rollerConfigPath, key, v)
+				if err != nil {
+					pterm.Error.Printf("failed to update %s: %v\n", key, err)
+					return
+				}
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			pterm.Info.Printf(
+				"bond policy: enabled=%t target_bond=%q min_buffer=%q\n",
+				rollerData.BondPolicy.Enabled,
+				rollerData.BondPolicy.TargetBond,
+				rollerData.BondPolicy.MinBuffer,
+			)
+		},
+	}
+
+	cmd.Flags().Bool("enabled", false, "enable automatic bond top-ups")
+	cmd.Flags().String("target-bond", "", "bond to maintain, e.g. 100000000000000000000adym")
+	cmd.Flags().String("min-buffer", "", "minimum amount to keep above the hub minimum bond")
+
+	return cmd
+}
@@ -1,17 +1,13 @@
 package unbond
 
 import (
-	"fmt"
-	"os/exec"
-	"path/filepath"
-
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
-	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -28,15 +24,19 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			c := exec.Command(
-				consts.Executables.Dymension, "tx",
-				"sequencer", "unbond", "--keyring-backend",
-				"test", "--from", consts.KeysIds.HubSequencer, "--keyring-dir", filepath.Join(
-					home,
-					consts.ConfigDirName.HubKeys,
-				), "--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
-				"--node", rollerData.HubData.RPC_URL, "--chain-id", rollerData.HubData.ID,
-			)
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if dryRun {
+				s, err := sequencer.SimulateBondTx(rollerData, sequencer.BondActionUnbond, "")
+				if err != nil {
+					pterm.Error.Println("failed to simulate bond transaction: ", err)
+					return
+				}
+
+				s.Print()
+				return
+			}
+
+			c := sequencer.GetBondTxCmd(rollerData, sequencer.BondActionUnbond, "")
 
 			txOutput, err := bash.ExecCommandWithInput(c, "signatures")
 			if err != nil {
@@ -58,5 +58,7 @@ func Cmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("dry-run", false, "simulate the transaction without broadcasting it")
+
 	return cmd
 }
@@ -0,0 +1,210 @@
+package handover
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/restart"
+	"github.com/dymensionxyz/roller/cmd/utils"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/nodetype"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const proposerPollInterval = 10 * time.Second
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "handover",
+		Short: "Hand over the proposer role between two of your sequencers",
+		Long: `Hand over the proposer role between two of your sequencers.
+
+Run with --successor on the current proposer: roller verifies that the successor is
+registered and bonded, unbonds the current proposer to initiate the rotation on the
+hub, waits for the proposer to change and switches the local node to a full node.
+
+Run with --incoming on the successor: roller waits until the hub selects the local
+sequencer as the proposer and switches the local node to a sequencer.`,
+		Example: `  roller rollapp sequencer handover --successor dym1...
+  roller rollapp sequencer handover --incoming`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			successor, _ := cmd.Flags().GetString("successor")
+			incoming, _ := cmd.Flags().GetBool("incoming")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			switch {
+			case incoming && successor == "":
+				err = receiveHandover(rollerData, timeout)
+			case !incoming && successor != "":
+				err = initiateHandover(rollerData, successor, timeout)
+			default:
+				err = errors.New("exactly one of --successor or --incoming has to be provided")
+			}
+
+			if err != nil {
+				pterm.Error.Println("handover failed:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().String("successor", "", "hub address of the sequencer that takes over as the proposer")
+	cmd.Flags().Bool("incoming", false, "take over the proposer role on this node")
+	cmd.Flags().
+		Duration("timeout", 0, "maximum time to wait for the proposer to change, 0 waits indefinitely")
+
+	return cmd
+}
+
+func initiateHandover(rollerData roller.RollappConfig, successor string, timeout time.Duration) error {
+	if rollerData.NodeType != consts.NodeType.Sequencer {
+		return errors.New("the local node is not running as a sequencer")
+	}
+
+	address, err := sequencer.GetHubSequencerAddress(rollerData)
+	if err != nil {
+		return err
+	}
+
+	if address == successor {
+		return errors.New("the successor has to be a different sequencer")
+	}
+
+	pterm.Info.Println("checking the current proposer")
+	proposer, err := rollapp.GetCurrentProposer(rollerData.RollappID, rollerData.HubData)
+	if err != nil {
+		return err
+	}
+	if proposer != address {
+		return fmt.Errorf("the local sequencer %s is not the current proposer (%s)", address, proposer)
+	}
+
+	pterm.Info.Printf("checking the successor %s\n", successor)
+	_, err = sequencer.ValidateSuccessor(successor, rollerData.RollappID, rollerData.HubData)
+	if err != nil {
+		return err
+	}
+	pterm.Success.Println("successor is registered and bonded")
+
+	proceed, _ := utils.PromptBool(
+		fmt.Sprintf("unbond %s to hand over the proposer role", address),
+	)
+	if !proceed {
+		return errors.New("cancelled by user")
+	}
+
+	pterm.Info.Println("unbonding the current proposer to initiate the rotation")
+	c := sequencer.GetBondTxCmd(rollerData, sequencer.BondActionUnbond, "")
+	txOutput, err := bash.ExecCommandWithInput(c, "signatures")
+	if err != nil {
+		return err
+	}
+
+	txHash, err := bash.ExtractTxHash(txOutput)
+	if err != nil {
+		return err
+	}
+
+	err = tx.MonitorTransaction(rollerData.HubData.RPC_URL, txHash)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start("waiting for the hub to rotate the proposer")
+	newProposer, err := sequencer.WaitForProposer(
+		rollerData.RollappID,
+		rollerData.HubData,
+		proposerPollInterval,
+		timeout,
+		func(p string) bool { return p != address },
+	)
+	if err != nil {
+		spinner.Fail(err.Error())
+		return err
+	}
+	spinner.Success(fmt.Sprintf("the proposer is now %s", newProposer))
+
+	if newProposer != successor {
+		pterm.Warning.Printf(
+			"the hub selected %s as the proposer instead of %s\n",
+			newProposer,
+			successor,
+		)
+	}
+
+	return switchNodeType(rollerData, consts.NodeType.FullNode)
+}
+
+func receiveHandover(rollerData roller.RollappConfig, timeout time.Duration) error {
+	if rollerData.NodeType == consts.NodeType.Sequencer {
+		return errors.New("the local node is already running as a sequencer")
+	}
+
+	address, err := sequencer.GetHubSequencerAddress(rollerData)
+	if err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("checking the local sequencer %s\n", address)
+	_, err = sequencer.ValidateSuccessor(address, rollerData.RollappID, rollerData.HubData)
+	if err != nil {
+		return err
+	}
+	pterm.Success.Println("local sequencer is registered and bonded")
+
+	spinner, _ := pterm.DefaultSpinner.Start("waiting for the local sequencer to become the proposer")
+	_, err = sequencer.WaitForProposer(
+		rollerData.RollappID,
+		rollerData.HubData,
+		proposerPollInterval,
+		timeout,
+		func(p string) bool { return p == address },
+	)
+	if err != nil {
+		spinner.Fail(err.Error())
+		return err
+	}
+	spinner.Success("the local sequencer is now the proposer")
+
+	return switchNodeType(rollerData, consts.NodeType.Sequencer)
+}
+
+func switchNodeType(rollerData roller.RollappConfig, nt string) error {
+	pterm.Info.Printf("switching the local node to %s\n", nt)
+	err := nodetype.Switch(rollerData, nt)
+	if err != nil {
+		return err
+	}
+
+	err = restart.RestartSystemdServices([]string{"rollapp"}, rollerData.Home)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("handover complete, the local node is running as a %s\n", nt)
+	return nil
+}
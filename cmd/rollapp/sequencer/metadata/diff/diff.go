@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [metadata-file.json]",
+		Short: "Validate a sequencer metadata file and compare it with the on-chain metadata",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := initconfig.AddFlags(cmd)
+			if err != nil {
+				pterm.Error.Println("failed to add flags")
+				return
+			}
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			metadataFilePath := filepath.Join(
+				home, consts.ConfigDirName.Rollapp, "init",
+				"sequencer-metadata.json",
+			)
+			if len(args) != 0 {
+				metadataFilePath = args[0]
+			}
+
+			skipEndpoints, _ := cmd.Flags().GetBool("skip-endpoint-checks")
+
+			diffs, valid, err := sequencer.CheckMetadataFile(
+				rollerData,
+				metadataFilePath,
+				!skipEndpoints,
+			)
+			if err != nil {
+				pterm.Error.Println("failed to compare metadata", err)
+				return
+			}
+
+			sequencer.PrintMetadataDiff(diffs)
+
+			if !valid {
+				pterm.Error.Println("metadata file is invalid")
+				return
+			}
+		},
+	}
+
+	cmd.Flags().Bool("skip-endpoint-checks", false, "don't check if the endpoints are reachable")
+
+	return cmd
+}
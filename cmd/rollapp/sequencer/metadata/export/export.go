@@ -118,6 +118,11 @@ func Cmd() *cobra.Command {
 			defer func() {
 				pterm.Info.Println("next steps:")
 				pterm.Info.Println("update the metadata file")
+				pterm.Info.Printf(
+					"run %s to validate the file and review the changes\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller rollapp sequencer metadata diff"),
+				)
 				pterm.Info.Printf(
 					"run %s to submit a transaction to update the sequencer metadata\n",
 					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
@@ -3,6 +3,7 @@ package metadata
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata/diff"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata/export"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata/update"
 )
@@ -14,6 +15,7 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(export.Cmd())
+	cmd.AddCommand(diff.Cmd())
 	cmd.AddCommand(update.Cmd())
 
 	return cmd
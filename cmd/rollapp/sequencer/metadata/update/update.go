@@ -2,6 +2,7 @@ package update
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -12,9 +13,11 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/tx/tx_utils"
+	"github.com/dymensionxyz/roller/cmd/utils"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -22,6 +25,7 @@ func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update [metadata-file.json]",
 		Short: "Update the sequencer metadata",
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			err := initconfig.AddFlags(cmd)
 			if err != nil {
@@ -43,55 +47,104 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			metadataFilePath := filepath.Join(
-				home, consts.ConfigDirName.Rollapp, "init",
-				"sequencer-metadata.json",
-			)
-
-			updateSeqCmd := exec.Command(
-				consts.Executables.Dymension,
-				"tx",
-				"sequencer",
-				"update-sequencer",
-				metadataFilePath,
-				"--from",
-				consts.KeysIds.HubSequencer,
-				"--keyring-backend",
-				"test",
-				"--fees",
-				fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
-				"--gas-adjustment",
-				"1.3",
-				"--keyring-dir",
-				filepath.Join(roller.GetRootDir(), consts.ConfigDirName.HubKeys),
-				"--node", rollerData.HubData.RPC_URL, "--chain-id", rollerData.HubData.ID,
-			)
-
-			txOutput, err := bash.ExecCommandWithInput(updateSeqCmd, "signatures")
-			if err != nil {
-				pterm.Error.Println("failed to update sequencer metadata", err)
-				return
-			}
-
-			tob := bytes.NewBufferString(txOutput)
-			err = tx_utils.CheckTxYamlStdOut(*tob)
-			if err != nil {
-				pterm.Error.Println("failed to check raw_log", err)
-				return
-			}
-
-			txHash, err := bash.ExtractTxHash(txOutput)
-			if err != nil {
-				return
+			metadataFilePath := GetMetadataFilePath(home)
+			if len(args) != 0 {
+				metadataFilePath = args[0]
 			}
 
-			err = tx.MonitorTransaction(rollerData.HubData.RPC_URL, txHash)
+			skipEndpoints, _ := cmd.Flags().GetBool("skip-endpoint-checks")
+			_, err = UpdateMetadata(rollerData, metadataFilePath, !skipEndpoints)
 			if err != nil {
-				pterm.Error.Println("transaction failed", err)
+				pterm.Error.Println("failed to update sequencer metadata:", err)
 				return
 			}
 		},
 	}
 
+	cmd.Flags().Bool("skip-endpoint-checks", false, "don't check if the endpoints are reachable")
+
 	return cmd
 }
+
+func GetMetadataFilePath(home string) string {
+	return filepath.Join(
+		home, consts.ConfigDirName.Rollapp, "init",
+		"sequencer-metadata.json",
+	)
+}
+
+// UpdateMetadata validates the metadata file, shows the changes compared to
+// the on-chain metadata and submits the update after a confirmation. Returns
+// whether the update was submitted
+func UpdateMetadata(
+	rollerData roller.RollappConfig,
+	metadataFilePath string,
+	checkEndpoints bool,
+) (bool, error) {
+	diffs, valid, err := sequencer.CheckMetadataFile(
+		rollerData,
+		metadataFilePath,
+		checkEndpoints,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare metadata: %w", err)
+	}
+
+	if !valid {
+		return false, errors.New("metadata file is invalid, fix the errors above and try again")
+	}
+
+	if len(diffs) == 0 {
+		pterm.Info.Println("no changes compared to the on-chain metadata, nothing to update")
+		return false, nil
+	}
+
+	sequencer.PrintMetadataDiff(diffs)
+	proceed, _ := utils.PromptBool("submit the metadata update")
+	if !proceed {
+		pterm.Info.Println("exiting")
+		return false, nil
+	}
+
+	updateSeqCmd := exec.Command(
+		consts.Executables.Dymension,
+		"tx",
+		"sequencer",
+		"update-sequencer",
+		metadataFilePath,
+		"--from",
+		consts.KeysIds.HubSequencer,
+		"--keyring-backend",
+		"test",
+		"--fees",
+		fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		"--gas-adjustment",
+		"1.3",
+		"--keyring-dir",
+		filepath.Join(roller.GetRootDir(), consts.ConfigDirName.HubKeys),
+		"--node", rollerData.HubData.RPC_URL, "--chain-id", rollerData.HubData.ID,
+	)
+
+	txOutput, err := bash.ExecCommandWithInput(updateSeqCmd, "signatures")
+	if err != nil {
+		return false, err
+	}
+
+	tob := bytes.NewBufferString(txOutput)
+	err = tx_utils.CheckTxYamlStdOut(*tob)
+	if err != nil {
+		return false, fmt.Errorf("failed to check raw_log: %w", err)
+	}
+
+	txHash, err := bash.ExtractTxHash(txOutput)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.MonitorTransaction(rollerData.HubData.RPC_URL, txHash)
+	if err != nil {
+		return false, fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return true, nil
+}
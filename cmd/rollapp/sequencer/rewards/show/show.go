@@ -0,0 +1,116 @@
+package show
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rewards"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the sequencer rewards accrued on the rollapp and the hub",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := initconfig.AddFlags(cmd)
+			if err != nil {
+				pterm.Error.Println("failed to add flags")
+				return
+			}
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			td := pterm.TableData{{"Chain", "Address", "Balance", "Sweep"}}
+
+			raDenom := rewards.RollappBaseDenom(rollerData)
+			raAddress := rollerData.Rewards.Address
+			if a, err := rewards.GetRollappAccount(rollerData); err == nil && raAddress == "" {
+				raAddress = a.Address
+			}
+
+			if raAddress == "" {
+				pterm.Warning.Println(
+					"rollapp rewards address is not set, run 'roller rollapp sequencer rewards set-address'",
+				)
+			} else {
+				td = append(
+					td,
+					accountRow(
+						rewards.ChainRollapp,
+						raAddress,
+						raDenom,
+						rollerData.Rewards.RollappSweep,
+						func() (string, error) {
+							b, err := rewards.QueryBalance(
+								rollerData.RollappBinary,
+								"http://localhost:26657",
+								raDenom,
+								raAddress,
+							)
+							return b.String(), err
+						},
+					),
+				)
+			}
+
+			hubAccount, err := rewards.GetHubAccount(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve the hub sequencer address", err)
+				return
+			}
+			td = append(
+				td,
+				accountRow(
+					rewards.ChainHub,
+					hubAccount.Address,
+					hubAccount.Denom,
+					rollerData.Rewards.HubSweep,
+					func() (string, error) {
+						b, err := hubAccount.Balance()
+						return b.String(), err
+					},
+				),
+			)
+
+			pterm.DefaultSection.WithIndentCharacter("💈").Println("Sequencer rewards")
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
+
+func accountRow(
+	chain, address, denom string,
+	s roller.RewardsSweep,
+	balance func() (string, error),
+) []string {
+	b, err := balance()
+	if err != nil {
+		b = "unavailable"
+	} else {
+		b += denom
+	}
+
+	sweep := "disabled"
+	if s.Enabled {
+		sweep = "above " + s.Threshold + " to " + s.Destination
+	}
+
+	return []string{chain, address, b, sweep}
+}
@@ -0,0 +1,102 @@
+package sweep
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rewards"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Configure automatic reward sweeps performed by the health agent",
+		Example: "  roller rollapp sequencer rewards sweep --chain rollapp --enabled " +
+			"--destination ethm1... --threshold 1000000000000000000",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			chain, _ := cmd.Flags().GetString("chain")
+			if chain != rewards.ChainRollapp && chain != rewards.ChainHub {
+				pterm.Error.Printf("unsupported chain %s\n", chain)
+				return
+			}
+
+			rollerConfigPath := roller.GetConfigPath(home)
+			prefix := fmt.Sprintf("rewards.%s_sweep", chain)
+
+			for _, flag := range []string{"enabled", "destination", "threshold", "reserve"} {
+				if !cmd.Flags().Changed(flag) {
+					continue
+				}
+
+				var v any
+				if flag == "enabled" {
+					v, _ = cmd.Flags().GetBool(flag)
+				} else {
+					v, _ = cmd.Flags().GetString(flag)
+				}
+
+				err = tomlconfig.UpdateFieldInFile(rollerConfigPath, prefix+"."+flag, v)
+				if err != nil {
+					pterm.Error.Printf("failed to update %s.%s: %v\n", prefix, flag, err)
+					return
+				}
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			s := rollerData.Rewards.RollappSweep
+			if chain == rewards.ChainHub {
+				s = rollerData.Rewards.HubSweep
+			}
+
+			if chain == rewards.ChainHub && s.Enabled && s.Reserve == "" {
+				pterm.Warning.Printf(
+					"the hub sequencer account pays the bond top ups and fees, it isn't swept "+
+						"until a reserve is set with --reserve (at least %s%s is always kept)\n",
+					rewards.HubFeeBudget().String(),
+					consts.Denoms.Hub,
+				)
+			}
+
+			if s.Enabled && s.Destination == "" {
+				pterm.Warning.Println("sweep is enabled but no destination is set")
+			}
+
+			pterm.Info.Printf(
+				"%s sweep: enabled=%t destination=%q threshold=%q reserve=%q\n",
+				chain,
+				s.Enabled,
+				s.Destination,
+				s.Threshold,
+				s.Reserve,
+			)
+		},
+	}
+
+	cmd.Flags().String("chain", rewards.ChainRollapp, "account to sweep, 'rollapp' or 'hub'")
+	cmd.Flags().Bool("enabled", false, "enable the automatic sweep")
+	cmd.Flags().String("destination", "", "address that receives the swept rewards")
+	cmd.Flags().String("threshold", "", "sweep once the balance above the reserve exceeds this amount")
+	cmd.Flags().String("reserve", "", "amount to keep in the account")
+
+	return cmd
+}
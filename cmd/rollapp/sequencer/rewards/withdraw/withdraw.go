@@ -0,0 +1,103 @@
+package withdraw
+
+import (
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rewards"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw <destination-address>",
+		Short: "Withdraw sequencer rewards to the destination address",
+		Example: `  roller rollapp sequencer rewards withdraw ethm1... --chain rollapp
+  roller rollapp sequencer rewards withdraw dym1... --chain hub --amount 1000000000000000000adym --dry-run`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := initconfig.AddFlags(cmd)
+			if err != nil {
+				pterm.Error.Println("failed to add flags")
+				return
+			}
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			chain, _ := cmd.Flags().GetString("chain")
+			amountStr, _ := cmd.Flags().GetString("amount")
+			reserveStr, _ := cmd.Flags().GetString("reserve")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			account, err := rewards.GetAccount(rollerData, chain)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve rewards account:", err)
+				return
+			}
+
+			var amount *cosmossdkmath.Int
+			if amountStr != "" {
+				amt, err := rewards.ParseAmount(amountStr, account.Denom)
+				if err != nil {
+					pterm.Error.Println("invalid amount:", err)
+					return
+				}
+				amount = &amt
+			}
+
+			reserve, err := rewards.ParseAmount(reserveStr, account.Denom)
+			if err != nil {
+				pterm.Error.Println("invalid reserve:", err)
+				return
+			}
+
+			w, err := rewards.NewWithdrawal(account, args[0], amount, reserve)
+			if err != nil {
+				pterm.Error.Println("failed to simulate withdrawal:", err)
+				return
+			}
+
+			w.Print()
+			if dryRun {
+				return
+			}
+
+			err = w.Broadcast(true)
+			if err != nil {
+				pterm.Error.Println("failed to withdraw rewards:", err)
+				return
+			}
+
+			pterm.Success.Printf(
+				"withdrew %s%s to %s\n",
+				w.Amount.String(),
+				account.Denom,
+				args[0],
+			)
+		},
+	}
+
+	cmd.Flags().
+		String("chain", rewards.ChainRollapp, "chain to withdraw from, 'rollapp' or 'hub'")
+	cmd.Flags().
+		String("amount", "", "amount to withdraw in the base denom, defaults to everything above the reserve")
+	cmd.Flags().String("reserve", "", "amount to keep in the account when withdrawing everything")
+	cmd.Flags().Bool("dry-run", false, "simulate the withdrawal without broadcasting it")
+
+	return cmd
+}
@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond"
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/handover"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/rewards"
 	"github.com/spf13/cobra"
@@ -16,6 +17,7 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(metadata.Cmd())
 	cmd.AddCommand(rewards.Cmd())
 	cmd.AddCommand(bond.Cmd())
+	cmd.AddCommand(handover.Cmd())
 
 	return cmd
 }
@@ -8,7 +8,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -22,6 +21,7 @@ import (
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/restore"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
 	"github.com/dymensionxyz/roller/data_layer/celestia"
 	"github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
@@ -410,57 +410,9 @@ func Cmd() *cobra.Command {
 					}
 
 					if !dataDirNotEmpty || replaceExistingData {
-						// TODO: this should be a util "RecreateDir"
-						err = os.RemoveAll(dataDir)
+						_, err = restore.Restore(home, si.SnapshotUrl, si.Checksum, false, false)
 						if err != nil {
-							pterm.Error.Printf("failed to remove %s dir: %v", dataDir, err)
-							return
-						}
-
-						err = os.MkdirAll(dataDir, 0o755)
-						if err != nil {
-							pterm.Error.Printf("failed to create %s: %v\n", dataDir, err)
-							return
-						}
-
-						tmpDir, err := os.MkdirTemp("", "download-*")
-						if err != nil {
-							fmt.Printf("Error creating temp directory: %v\n", err)
-							return
-						}
-
-						// Print the path of the temporary directory
-						fmt.Printf("Temporary directory created: %s\n", tmpDir)
-
-						// The directory will be deleted when the program exits
-						// nolint:errcheck,gosec
-						defer os.RemoveAll(tmpDir)
-						archivePath := filepath.Join(tmpDir, "backup.tar.gz")
-						spinner, _ := pterm.DefaultSpinner.Start("downloading file...")
-						downloadedFileHash, err := filesystem.DownloadAndSaveArchive(
-							si.SnapshotUrl,
-							archivePath,
-						)
-						if err != nil {
-							spinner.Fail(fmt.Sprintf("error downloading file: %v", err))
-							os.Exit(1)
-						}
-						spinner.Success("file downloaded successfully")
-
-						// compare the checksum
-						if downloadedFileHash != si.Checksum {
-							pterm.Error.Printf(
-								"snapshot archive checksum mismatch, have: %s, want: %s.",
-								downloadedFileHash,
-								si.Checksum,
-							)
-
-							return
-						}
-
-						err = filesystem.ExtractTarGz(archivePath, filepath.Join(RollappDirPath))
-						if err != nil {
-							pterm.Error.Println("failed to extract snapshot: ", err)
+							pterm.Error.Println("failed to restore snapshot: ", err)
 							return
 						}
 					}
@@ -468,7 +420,11 @@ func Cmd() *cobra.Command {
 			}
 
 			// DA
-			damanager := datalayer.NewDAManager(rollappConfig.DA.Backend, rollappConfig.Home)
+			damanager, err := datalayer.NewDAManager(rollappConfig.DA.Backend, rollappConfig.Home)
+			if err != nil {
+				pterm.Error.Println("failed to load the DA: ", err)
+				return
+			}
 			daHome := filepath.Join(
 				damanager.GetRootDirectory(),
 				consts.ConfigDirName.DALightNode,
@@ -499,32 +455,86 @@ func Cmd() *cobra.Command {
 				daWalletInfo.Mnemonic = mnemonic
 				daWalletInfo.Print(keys.WithMnemonic(), keys.WithName())
 
-				daSpinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone(true).
-					Start("initializing da light client")
-				daSpinner.UpdateText("checking for state update ")
-				cmd := exec.Command(
-					consts.Executables.Dymension,
-					"q",
-					"rollapp",
-					"state",
-					rollappConfig.RollappID,
-					"--index",
-					"1",
-					"--node",
-					hd.RPC_URL,
-					"--chain-id", hd.ID,
-				)
+				// only the celestia light client syncs from the height of the first
+				// state update
+				if rollappConfig.DA.Backend == consts.Celestia {
+					daSpinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone(true).
+						Start("initializing da light client")
+					daSpinner.UpdateText("checking for state update ")
+					cmd := exec.Command(
+						consts.Executables.Dymension,
+						"q",
+						"rollapp",
+						"state",
+						rollappConfig.RollappID,
+						"--index",
+						"1",
+						"--node",
+						hd.RPC_URL,
+						"--chain-id", hd.ID,
+					)
 
-				out, err := bash.ExecCommandWithStdout(cmd)
-				if err != nil {
-					if strings.Contains(out.String(), "key not found") {
-						pterm.Info.Printf(
-							"no state found for %s, da light client will be initialized with latest height",
-							rollappConfig.RollappID,
-						)
+					out, err := bash.ExecCommandWithStdout(cmd)
+					if err != nil {
+						if strings.Contains(out.String(), "key not found") {
+							pterm.Info.Printf(
+								"no state found for %s, da light client will be initialized with latest height",
+								rollappConfig.RollappID,
+							)
+
+							height, blockIdHash, err := celestia.GetLatestBlock(rollerData)
+							if err != nil {
+								return
+							}
+
+							heightInt, err := strconv.Atoi(height)
+							if err != nil {
+								pterm.Error.Println("failed to convert height to int: ", err)
+								return
+							}
+
+							celestiaConfigFilePath := filepath.Join(
+								home,
+								consts.ConfigDirName.DALightNode,
+								"config.toml",
+							)
+
+							pterm.Info.Printf("updating %s \n", celestiaConfigFilePath)
+							err = lightclient.UpdateConfig(
+								celestiaConfigFilePath,
+								blockIdHash,
+								heightInt,
+							)
+							if err != nil {
+								pterm.Error.Println("failed to update celestia config: ", err)
+								return
+							}
+						} else {
+							pterm.Error.Println("failed to retrieve rollapp state update: ", err)
+							return
+						}
+						// nolint:errcheck,gosec
+						daSpinner.Stop()
+					} else {
+						daSpinner.UpdateText("state update found, extracting da height")
+						// nolint:errcheck,gosec
+						daSpinner.Stop()
 
-						height, blockIdHash, err := celestia.GetLatestBlock(rollerData)
+						var result lightclient.RollappStateResponse
+						if err := yaml.Unmarshal(out.Bytes(), &result); err != nil {
+							pterm.Error.Println("failed to unmarshal result: ", err)
+							return
+						}
+
+						h, err := celestia.ExtractHeightfromDAPath(result.StateInfo.DAPath)
+						if err != nil {
+							pterm.Error.Println("failed to extract height: ", err)
+							return
+						}
+
+						height, hash, err := celestia.GetBlockByHeight(h, rollerData)
 						if err != nil {
+							pterm.Error.Println("failed to retrieve block: ", err)
 							return
 						}
 
@@ -540,68 +550,18 @@ func Cmd() *cobra.Command {
 							"config.toml",
 						)
 
-						pterm.Info.Printf("updating %s \n", celestiaConfigFilePath)
-						err = lightclient.UpdateConfig(
-							celestiaConfigFilePath,
-							blockIdHash,
-							heightInt,
+						pterm.Info.Printf(
+							"the first %s state update has DA height of %s with hash %s\n",
+							rollappConfig.RollappID,
+							height,
+							hash,
 						)
+						pterm.Info.Printf("updating %s \n", celestiaConfigFilePath)
+						err = lightclient.UpdateConfig(celestiaConfigFilePath, hash, heightInt)
 						if err != nil {
 							pterm.Error.Println("failed to update celestia config: ", err)
 							return
 						}
-					} else {
-						pterm.Error.Println("failed to retrieve rollapp state update: ", err)
-						return
-					}
-					// nolint:errcheck,gosec
-					daSpinner.Stop()
-				} else {
-					daSpinner.UpdateText("state update found, extracting da height")
-					// nolint:errcheck,gosec
-					daSpinner.Stop()
-
-					var result lightclient.RollappStateResponse
-					if err := yaml.Unmarshal(out.Bytes(), &result); err != nil {
-						pterm.Error.Println("failed to unmarshal result: ", err)
-						return
-					}
-
-					h, err := celestia.ExtractHeightfromDAPath(result.StateInfo.DAPath)
-					if err != nil {
-						pterm.Error.Println("failed to extract height: ", err)
-						return
-					}
-
-					height, hash, err := celestia.GetBlockByHeight(h, rollerData)
-					if err != nil {
-						pterm.Error.Println("failed to retrieve block: ", err)
-						return
-					}
-
-					heightInt, err := strconv.Atoi(height)
-					if err != nil {
-						pterm.Error.Println("failed to convert height to int: ", err)
-						return
-					}
-
-					celestiaConfigFilePath := filepath.Join(
-						home,
-						consts.ConfigDirName.DALightNode,
-						"config.toml",
-					)
-
-					pterm.Info.Printf(
-						"the first %s state update has DA height of %s with hash %s\n",
-						rollappConfig.RollappID,
-						height,
-						hash,
-					)
-					pterm.Info.Printf("updating %s \n", celestiaConfigFilePath)
-					err = lightclient.UpdateConfig(celestiaConfigFilePath, hash, heightInt)
-					if err != nil {
-						pterm.Error.Println("failed to update celestia config: ", err)
-						return
 					}
 				}
 			}
@@ -635,14 +595,50 @@ func Cmd() *cobra.Command {
 				}
 
 				// TODO: daconfig should be a struct
-				daConfig = damanager.DataLayer.GetSequencerDAConfig(
+				daConfig, err = damanager.DataLayer.GetSequencerDAConfig(
 					consts.NodeType.Sequencer,
 				)
+				if err != nil {
+					pterm.Error.Println("failed to generate the DA config: ", err)
+					return
+				}
 
 			case "fullnode":
-				daConfig = damanager.DataLayer.GetSequencerDAConfig(
+				// full nodes read the batches from the namespace the sequencer
+				// posts to, it's taken from the latest state update
+				if rollappConfig.DA.Backend == consts.Celestia &&
+					damanager.DataLayer.GetNamespaceID() == "" {
+					pterm.Info.Println("retrieving the DA namespace from the hub")
+					ns, err := celestia.NamespaceIDFromHub(*rollappConfig)
+					if err != nil {
+						pterm.Error.Println("failed to retrieve the DA namespace from the hub:", err)
+						pterm.Info.Printf(
+							"set it with %s and run the setup again\n",
+							pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+								Sprint("roller da namespace set <namespace-id>"),
+						)
+						return
+					}
+
+					rollappConfig.DA.NamespaceID = ns
+					err = tomlconfig.UpdateFieldInFile(
+						roller.GetConfigPath(home),
+						"DA.namespace_id",
+						ns,
+					)
+					if err != nil {
+						pterm.Error.Println("failed to update the DA namespace:", err)
+						return
+					}
+				}
+
+				daConfig, err = damanager.DataLayer.GetSequencerDAConfig(
 					consts.NodeType.FullNode,
 				)
+				if err != nil {
+					pterm.Error.Println("failed to generate the DA config: ", err)
+					return
+				}
 
 				vtu := map[string]string{
 					"p2p_advertising_enabled": "true",
@@ -697,23 +693,26 @@ func Cmd() *cobra.Command {
 
 			}
 
-			daNamespace := damanager.DataLayer.GetNamespaceID()
-			if daNamespace == "" {
-				pterm.Error.Println("failed to retrieve da namespace id")
-				return
-			}
-
 			pterm.Info.Println("updating dymint configuration")
 			_ = tomlconfig.UpdateFieldInFile(
 				dymintConfigPath,
 				"da_layer",
 				string(rollappConfig.DA.Backend),
 			)
-			_ = tomlconfig.UpdateFieldInFile(
-				dymintConfigPath,
-				"namespace_id",
-				daNamespace,
-			)
+
+			if rollappConfig.DA.Backend == consts.Celestia {
+				daNamespace := damanager.DataLayer.GetNamespaceID()
+				if daNamespace == "" {
+					pterm.Error.Println("failed to retrieve da namespace id")
+					return
+				}
+
+				_ = tomlconfig.UpdateFieldInFile(
+					dymintConfigPath,
+					"namespace_id",
+					daNamespace,
+				)
+			}
 			_ = tomlconfig.UpdateFieldInFile(
 				dymintConfigPath,
 				"da_config",
@@ -801,7 +800,7 @@ func populateSequencerMetadata(raCfg roller.RollappConfig) error {
 			rpc = "https://" + rpc
 		}
 
-		isValid := isValidURL(rpc)
+		isValid := sequencer.IsValidURL(rpc)
 
 		// Validate the URL
 		if !isValid {
@@ -821,7 +820,7 @@ func populateSequencerMetadata(raCfg roller.RollappConfig) error {
 			rest = "https://" + rest
 		}
 
-		isValid := isValidURL(rest)
+		isValid := sequencer.IsValidURL(rest)
 
 		// Validate the URL
 		if !isValid {
@@ -841,7 +840,7 @@ func populateSequencerMetadata(raCfg roller.RollappConfig) error {
 			evmRpc = "https://" + evmRpc
 		}
 
-		isValid := isValidURL(evmRpc)
+		isValid := sequencer.IsValidURL(evmRpc)
 
 		// Validate the URL
 		if !isValid {
@@ -890,12 +889,6 @@ func populateSequencerMetadata(raCfg roller.RollappConfig) error {
 	return nil
 }
 
-func isValidURL(url string) bool {
-	regex := `^(https?:\/\/)?([\da-z\.-]+)\.([a-z\.]{2,6})([\/\w \.-]*)*\/?$`
-	re := regexp.MustCompile(regex)
-	return re.MatchString(url)
-}
-
 func WriteStructToJSONFile(data *dymensionseqtypes.SequencerMetadata, filePath string) error {
 	// Marshal the struct into JSON
 	jsonData, err := json.Marshal(data)
@@ -0,0 +1,136 @@
+package create
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/services/start"
+	"github.com/dymensionxyz/roller/cmd/services/stop"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/snapshot"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a snapshot of the rollapp data directory",
+		Long: `Create a snapshot of the rollapp data directory.
+
+A running rollapp service is stopped while the data directory is archived with tar and
+zstd and started again afterwards. The snapshot height is read from the stopped node
+and recorded together with the SHA-256 checksum next to the archive, snapshots
+exceeding the retention configured in roller.toml are pruned.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			height, _ := cmd.Flags().GetString("height")
+			noStop, _ := cmd.Flags().GetBool("no-stop")
+
+			services := []string{"rollapp"}
+			if !noStop {
+				running, err := servicemanager.IsServiceActive("rollapp")
+				if err != nil {
+					pterm.Error.Println("failed to check the rollapp service:", err)
+					return
+				}
+
+				if running {
+					err = stop.StopSystemdServices(services)
+					if err != nil {
+						pterm.Error.Println("failed to stop the rollapp:", err)
+						return
+					}
+
+					defer func() {
+						err := start.StartServices(services)
+						if err != nil {
+							pterm.Error.Println("failed to start the rollapp:", err)
+						}
+					}()
+				}
+			} else {
+				pterm.Warning.Println(
+					"creating a snapshot without stopping the rollapp, make sure it isn't running",
+				)
+			}
+
+			// the height is read once the node is stopped, blocks produced
+			// while it was stopping are part of the archive
+			if height == "" {
+				h, err := snapshot.StoredHeight(home)
+				if err != nil {
+					pterm.Error.Println(
+						"failed to read the rollapp height, provide it with --height:",
+						err,
+					)
+					return
+				}
+				height = strconv.FormatInt(h, 10)
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start("creating snapshot")
+			s, err := snapshot.Create(home, rollerData.RollappID, height)
+			if err != nil {
+				spinner.Fail("failed to create snapshot: ", err)
+				return
+			}
+			spinner.Success("snapshot created")
+
+			pterm.Info.Printf("file: %s\n", s.Path(home))
+			pterm.Info.Printf("height: %s\n", s.Height)
+			pterm.Info.Printf("checksum: %s\n", s.Checksum)
+			pterm.Info.Printf("size: %d bytes\n", s.Size)
+
+			var maxAge time.Duration
+			if rollerData.Snapshots.MaxAge != "" {
+				maxAge, err = time.ParseDuration(rollerData.Snapshots.MaxAge)
+				if err != nil {
+					pterm.Error.Println("invalid snapshots.max_age in roller.toml:", err)
+					return
+				}
+			}
+
+			removed, err := snapshot.Prune(home, rollerData.Snapshots.Keep, maxAge, false)
+			if err != nil {
+				pterm.Error.Println("failed to prune snapshots:", err)
+				return
+			}
+			for _, r := range removed {
+				pterm.Info.Printf("pruned %s\n", r.File)
+			}
+
+			defer func() {
+				pterm.Info.Println("next steps:")
+				pterm.Info.Println("upload the snapshot to a publicly accessible location")
+				pterm.Info.Printf(
+					"run %s to advertise it in the sequencer metadata\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller rollapp snapshot publish %s --url <url>", s.Height),
+				)
+			}()
+		},
+	}
+
+	cmd.Flags().String("height", "", "height of the snapshot, read from the stopped node by default")
+	cmd.Flags().Bool("no-stop", false, "don't stop the rollapp service while creating the snapshot")
+
+	return cmd
+}
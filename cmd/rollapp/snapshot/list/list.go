@@ -0,0 +1,68 @@
+package list
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/snapshot"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the local snapshots",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			snapshots, err := snapshot.List(home)
+			if err != nil {
+				pterm.Error.Println("failed to list snapshots:", err)
+				return
+			}
+
+			if len(snapshots) == 0 {
+				pterm.Info.Printf("no snapshots found in %s\n", snapshot.Dir(home))
+				return
+			}
+
+			td := pterm.TableData{{"File", "Height", "Size", "Created", "Checksum", "URL"}}
+			for _, s := range snapshots {
+				td = append(
+					td, []string{
+						s.File,
+						s.Height,
+						fmt.Sprintf("%.1f MiB", float64(s.Size)/(1<<20)),
+						s.CreatedAt.Local().Format(time.DateTime),
+						shortChecksum(s.Checksum),
+						s.URL,
+					},
+				)
+			}
+
+			_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+		},
+	}
+
+	return cmd
+}
+
+// shortChecksum abbreviates the checksum, the index might have been edited
+// by hand
+func shortChecksum(sum string) string {
+	if len(sum) <= 12 {
+		return sum
+	}
+
+	return sum[:12]
+}
@@ -0,0 +1,81 @@
+package prune
+
+import (
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/snapshot"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove local snapshots exceeding the retention",
+		Long: `Remove local snapshots exceeding the retention.
+
+The retention defaults to the [snapshots] section of roller.toml and can be overridden
+with --keep and --max-age. Published snapshots are only removed with --include-published.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			keep := rollerData.Snapshots.Keep
+			if cmd.Flags().Changed("keep") {
+				keep, _ = cmd.Flags().GetInt("keep")
+			}
+
+			maxAgeStr := rollerData.Snapshots.MaxAge
+			if cmd.Flags().Changed("max-age") {
+				maxAgeStr, _ = cmd.Flags().GetString("max-age")
+			}
+
+			var maxAge time.Duration
+			if maxAgeStr != "" {
+				maxAge, err = time.ParseDuration(maxAgeStr)
+				if err != nil {
+					pterm.Error.Println("invalid max age:", err)
+					return
+				}
+			}
+
+			if keep == 0 && maxAge == 0 {
+				pterm.Info.Println("no retention configured, nothing to prune")
+				return
+			}
+
+			includePublished, _ := cmd.Flags().GetBool("include-published")
+			removed, err := snapshot.Prune(home, keep, maxAge, includePublished)
+			if err != nil {
+				pterm.Error.Println("failed to prune snapshots:", err)
+				return
+			}
+
+			for _, r := range removed {
+				pterm.Info.Printf("removed %s\n", r.File)
+			}
+			pterm.Success.Printf("pruned %d snapshots\n", len(removed))
+		},
+	}
+
+	cmd.Flags().Int("keep", 0, "number of newest snapshots to keep, 0 keeps all")
+	cmd.Flags().String("max-age", "", "remove snapshots older than this duration, e.g. 168h")
+	cmd.Flags().Bool("include-published", false, "also remove snapshots advertised in the metadata")
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+package publish
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata/update"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/snapshot"
+	"github.com/dymensionxyz/roller/utils/structs"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "publish <snapshot-file|height> --url <url>",
+		Short:   "Add an uploaded snapshot to the sequencer metadata",
+		Example: "roller rollapp snapshot publish 150000 --url https://snapshots.example.com/rollapp-150000.tar.zst",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			url, _ := cmd.Flags().GetString("url")
+			if !sequencer.IsValidURL(url) {
+				pterm.Error.Printf("invalid snapshot url %q\n", url)
+				return
+			}
+
+			s, err := snapshot.Find(home, args[0])
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			address, err := sequencer.GetHubSequencerAddress(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve the hub sequencer address", err)
+				return
+			}
+
+			metadata, err := sequencer.GetMetadata(address, rollerData.HubData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve metadata, ", err)
+				return
+			}
+
+			// replace a previously published snapshot for the same height
+			snapshots := []*sequencer.SnapshotInfo{
+				{
+					SnapshotUrl: url,
+					Height:      s.Height,
+					Checksum:    s.Checksum,
+				},
+			}
+			for _, existing := range metadata.Snapshots {
+				if existing != nil && existing.Height != s.Height {
+					snapshots = append(snapshots, existing)
+				}
+			}
+			metadata.Snapshots = snapshots
+
+			// the local metadata file is left untouched, it may hold pending
+			// changes that weren't submitted yet
+			tmpDir, err := os.MkdirTemp(os.TempDir(), "snapshot-metadata")
+			if err != nil {
+				pterm.Error.Println("failed to create a temporary directory", err)
+				return
+			}
+			defer os.RemoveAll(tmpDir)
+
+			metadataFilePath := filepath.Join(
+				tmpDir,
+				filepath.Base(update.GetMetadataFilePath(home)),
+			)
+			err = structs.ExportStructToFile(*metadata, metadataFilePath)
+			if err != nil {
+				pterm.Error.Println("failed to export metadata", err)
+				return
+			}
+
+			skipEndpoints, _ := cmd.Flags().GetBool("skip-endpoint-checks")
+			updated, err := update.UpdateMetadata(rollerData, metadataFilePath, !skipEndpoints)
+			if err != nil {
+				pterm.Error.Println("failed to update sequencer metadata:", err)
+				return
+			}
+			if !updated {
+				return
+			}
+
+			s.URL = url
+			err = s.Save(home)
+			if err != nil {
+				pterm.Error.Println("failed to record the snapshot url:", err)
+				return
+			}
+
+			pterm.Success.Printf("snapshot at height %s published at %s\n", s.Height, url)
+		},
+	}
+
+	cmd.Flags().String("url", "", "public url the snapshot was uploaded to")
+	cmd.Flags().Bool("skip-endpoint-checks", false, "don't check if the metadata endpoints are reachable")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
@@ -0,0 +1,211 @@
+package restore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/services/start"
+	"github.com/dymensionxyz/roller/cmd/services/stop"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/snapshot"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the rollapp data directory from a snapshot",
+		Long: `Restore the rollapp data directory from a snapshot.
+
+The snapshot is downloaded and extracted in a single pass, interrupted downloads are
+resumed from where they stopped. The checksum of the archive is verified while it's
+being extracted and the current data directory is only replaced once it matches.
+The replaced data directory is kept as a backup, use --rollback to restore it.
+
+Both .tar.gz and .tar.zst archives are supported.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			url, _ := cmd.Flags().GetString("url")
+			file, _ := cmd.Flags().GetString("file")
+			latest, _ := cmd.Flags().GetBool("latest")
+			checksum, _ := cmd.Flags().GetString("checksum")
+			rollback, _ := cmd.Flags().GetBool("rollback")
+			noStop, _ := cmd.Flags().GetBool("no-stop")
+			skipSpaceCheck, _ := cmd.Flags().GetBool("skip-space-check")
+
+			services := []string{"rollapp"}
+			if !noStop {
+				err = stop.StopSystemdServices(services)
+				if err != nil {
+					pterm.Error.Println("failed to stop the rollapp:", err)
+					return
+				}
+
+				defer func() {
+					err := start.StartServices(services)
+					if err != nil {
+						pterm.Error.Println("failed to start the rollapp:", err)
+					}
+				}()
+			}
+
+			if rollback {
+				backup, err := snapshot.Rollback(home)
+				if err != nil {
+					pterm.Error.Println("failed to roll back the data directory:", err)
+					return
+				}
+				pterm.Success.Printf("data directory restored from %s\n", backup)
+				return
+			}
+
+			var source string
+			switch {
+			case url != "" && file == "" && !latest:
+				source = url
+			case file != "" && url == "" && !latest:
+				source, checksum, err = localSource(home, file, checksum)
+				if err != nil {
+					pterm.Error.Println("failed to find the snapshot:", err)
+					return
+				}
+			case latest && url == "" && file == "":
+				pterm.Info.Println("retrieving the latest available snapshot")
+				si, err := sequencer.GetLatestSnapshot(rollerData.RollappID, rollerData.HubData)
+				if err != nil {
+					pterm.Error.Println("failed to retrieve the latest snapshot:", err)
+					return
+				}
+				if si == nil {
+					pterm.Error.Printf("no snapshots were found for %s\n", rollerData.RollappID)
+					return
+				}
+
+				pterm.Info.Printf(
+					"found a snapshot for height %s\nchecksum: %s\nurl: %s\n",
+					si.Height,
+					si.Checksum,
+					si.SnapshotUrl,
+				)
+				source = si.SnapshotUrl
+				checksum = si.Checksum
+			default:
+				pterm.Error.Println("exactly one of --url, --file or --latest is required")
+				return
+			}
+
+			if checksum == "" {
+				pterm.Warning.Println("no checksum provided, the snapshot won't be verified")
+			}
+
+			res, err := Restore(home, source, checksum, true, skipSpaceCheck)
+			if err != nil {
+				pterm.Error.Println("failed to restore the snapshot:", err)
+				return
+			}
+
+			pterm.Info.Printf("checksum: %s\n", res.Checksum)
+			if res.BackupDir != "" {
+				pterm.Info.Printf(
+					"previous data directory backed up to %s, remove it once the node is healthy\n",
+					res.BackupDir,
+				)
+			}
+		},
+	}
+
+	cmd.Flags().String("url", "", "url of the snapshot archive")
+	cmd.Flags().String("file", "", "path, name or height of a local snapshot")
+	cmd.Flags().Bool("latest", false, "restore the latest snapshot advertised by the sequencer")
+	cmd.Flags().String("checksum", "", "expected sha-256 checksum of the snapshot archive")
+	cmd.Flags().Bool("rollback", false, "restore the data directory replaced by the last restore")
+	cmd.Flags().Bool("no-stop", false, "don't stop the rollapp service while restoring the snapshot")
+	cmd.Flags().Bool("skip-space-check", false, "skip the free disk space check")
+
+	return cmd
+}
+
+// Restore streams the snapshot from source into the rollapp data directory,
+// reporting the download progress with a progress bar
+func Restore(
+	home, source, checksum string,
+	keepBackup, skipSpaceCheck bool,
+) (*snapshot.RestoreResult, error) {
+	var pb *pterm.ProgressbarPrinter
+	onProgress := func(read, total int64) {
+		if total <= 0 {
+			return
+		}
+		if pb == nil {
+			pb, _ = pterm.DefaultProgressbar.
+				WithTotal(int(total >> 20)).
+				WithTitle("restoring snapshot (MiB)").
+				Start()
+		}
+		if diff := int(read>>20) - pb.Current; diff > 0 {
+			pb.Add(diff)
+		}
+	}
+
+	res, err := snapshot.Restore(
+		home, snapshot.RestoreOptions{
+			Source:         source,
+			Checksum:       checksum,
+			KeepBackup:     keepBackup,
+			SkipSpaceCheck: skipSpaceCheck,
+			OnProgress:     onProgress,
+		},
+	)
+	if pb != nil {
+		_, _ = pb.Stop()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pterm.Success.Println("snapshot restored")
+	return res, nil
+}
+
+// localSource resolves a local snapshot by path, or by the name or height of
+// a snapshot created with `roller rollapp snapshot create`, in which case the
+// recorded checksum is used unless one was provided
+func localSource(home, file, checksum string) (string, string, error) {
+	if _, err := os.Stat(file); err == nil {
+		return file, checksum, nil
+	}
+
+	if strings.ContainsRune(file, os.PathSeparator) {
+		return "", "", fmt.Errorf("%s doesn't exist", file)
+	}
+
+	s, err := snapshot.Find(home, file)
+	if err != nil {
+		return "", "", err
+	}
+
+	if checksum == "" {
+		checksum = s.Checksum
+	}
+
+	return s.Path(home), checksum, nil
+}
@@ -0,0 +1,26 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/create"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/list"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/prune"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/publish"
+	"github.com/dymensionxyz/roller/cmd/rollapp/snapshot/restore"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot [command]",
+		Short: "Commands to create, manage, publish and restore rollapp data snapshots",
+	}
+
+	cmd.AddCommand(create.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(prune.Cmd())
+	cmd.AddCommand(publish.Cmd())
+	cmd.AddCommand(restore.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,194 @@
+package standby
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/restart"
+	"github.com/dymensionxyz/roller/cmd/utils"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/nodetype"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/standby"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "standby",
+		Short: "Run the local full node as a hot standby for the sequencer",
+		Long: `Run the local full node as a hot standby for the sequencer.
+
+The standby watches the liveness of the active sequencer through the state updates
+it submits to the hub and the blocks the local node receives over P2P. Once both
+have been stale for the configured number of consecutive checks, the standby is
+promoted: the fence command is executed, the lease is acquired, the bond is topped
+up to the hub minimum when needed and the local node is switched to a sequencer.
+
+To prevent double-signing, promotion is aborted when the fence command fails or the
+lease is held by another node, and requires operator confirmation unless --auto is
+set. --auto requires a lease file shared with the sequencer or a fence command. The
+criteria, lease and fence command are configured in the [standby] section of
+roller.toml.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.NodeType != consts.NodeType.FullNode {
+				pterm.Error.Println("standby mode is only available for full nodes")
+				return
+			}
+
+			settings, err := standby.LoadSettings(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to load standby settings", err)
+				return
+			}
+
+			address, err := sequencer.GetHubSequencerAddress(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve the hub sequencer address", err)
+				return
+			}
+			address = strings.TrimSpace(address)
+
+			_, err = sequencer.GetSequencerInfo(address, rollerData.HubData)
+			if err != nil {
+				pterm.Error.Printf(
+					"%s is not registered as a sequencer, standby nodes require pre-registered keys: %v\n",
+					address,
+					err,
+				)
+				return
+			}
+
+			auto, _ := cmd.Flags().GetBool("auto")
+			if auto && !settings.CanFence() {
+				pterm.Error.Println(
+					"--auto requires standby.lease_file on storage shared with the sequencer or a standby.fence_command,",
+					"the default lease in the roller home isn't visible to other hosts",
+				)
+				return
+			}
+			monitor := standby.NewMonitor(rollerData, settings)
+
+			pterm.Info.Printf(
+				"watching the sequencer of %s, promoting after %d failed checks (state updates stale for %s, blocks stale for %s)\n",
+				rollerData.RollappID,
+				settings.FailureThreshold,
+				settings.StateUpdateTimeout,
+				settings.BlockTimeout,
+			)
+
+			for {
+				r := monitor.Check()
+				if r.Failed() {
+					pterm.Warning.Printf(
+						"sequencer liveness check failed (%d/%d): hub height %s, local height %d\n",
+						r.Failures,
+						settings.FailureThreshold,
+						r.HubHeight,
+						r.LocalHeight,
+					)
+				}
+
+				if monitor.ShouldPromote() {
+					err = promote(rollerData, settings, address, auto)
+					if err != nil {
+						pterm.Error.Println("promotion aborted:", err)
+						return
+					}
+					return
+				}
+
+				time.Sleep(settings.CheckInterval)
+			}
+		},
+	}
+
+	cmd.Flags().Bool("auto", false, "promote the standby without operator confirmation")
+
+	return cmd
+}
+
+func promote(
+	rollerData roller.RollappConfig,
+	settings *standby.Settings,
+	address string,
+	auto bool,
+) error {
+	pterm.Warning.Println("the active sequencer is considered dead")
+
+	if !auto {
+		proceed, _ := utils.PromptBool("promote this node to sequencer")
+		if !proceed {
+			return errors.New("cancelled by user")
+		}
+	}
+
+	if settings.FenceCommand != "" {
+		pterm.Info.Println("fencing the previous sequencer")
+		c := exec.Command("bash", "-c", settings.FenceCommand)
+		_, err := bash.ExecCommandWithStdout(c)
+		if err != nil {
+			return fmt.Errorf("fence command failed: %w", err)
+		}
+	} else {
+		pterm.Warning.Println("no fence command configured, relying on the lease only")
+	}
+
+	pterm.Info.Printf("acquiring the lease %s\n", settings.LeaseFile)
+	_, err := standby.AcquireLease(settings.LeaseFile, address, settings.LeaseTTL)
+	if err != nil {
+		return err
+	}
+
+	pterm.Info.Println("checking the sequencer bond")
+	status, err := sequencer.EnforceBondPolicy(rollerData)
+	if err != nil {
+		return fmt.Errorf("failed to bond: %w", err)
+	}
+	if status.Deficit.IsPositive() {
+		pterm.Info.Printf(
+			"increased the bond by %s%s\n",
+			status.Deficit.String(),
+			consts.Denoms.Hub,
+		)
+	}
+
+	pterm.Info.Println("switching the local node to sequencer")
+	err = nodetype.Switch(rollerData, consts.NodeType.Sequencer)
+	if err != nil {
+		return err
+	}
+
+	err = restart.RestartSystemdServices([]string{"rollapp"}, rollerData.Home)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Println(
+		"standby promoted, the node produces blocks once the hub selects it as the proposer",
+	)
+	return nil
+}
@@ -3,6 +3,7 @@ package start
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,9 +19,11 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/damock"
 	"github.com/dymensionxyz/roller/sequencer"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/logging"
 	"github.com/dymensionxyz/roller/utils/migrations"
 	"github.com/dymensionxyz/roller/utils/roller"
@@ -100,6 +103,14 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			if rollappConfig.DA.Backend == consts.Local {
+				err = startLocalDA(ctx, home)
+				if err != nil {
+					pterm.Error.Println("failed to start the local DA: ", err)
+					return
+				}
+			}
+
 			rollerLogger := logging.GetRollerLogger(rollappConfig.Home)
 
 			nodeID, err := dymint.GetNodeID(home)
@@ -206,8 +217,11 @@ func PrintOutput(
 
 	if isHealthy {
 		seqAddrData, err := sequencerutils.GetSequencerData(rlpCfg)
-		daManager := datalayer.NewDAManager(consts.Celestia, rlpCfg.Home)
-		celAddrData, errCel := daManager.GetDAAccData(rlpCfg)
+		var celAddrData []keys.AccountData
+		daManager, errCel := datalayer.NewDAManager(consts.Celestia, rlpCfg.Home)
+		if errCel == nil {
+			celAddrData, errCel = daManager.GetDAAccData(rlpCfg)
+		}
 		if err != nil {
 			return
 		}
@@ -268,3 +282,37 @@ func parseError(errMsg string) string {
 	}
 	return errMsg
 }
+
+// startLocalDA runs the local DA of the mock backend in-process, unless it's
+// already served by `roller da local start`
+func startLocalDA(ctx context.Context, home string) error {
+	_, err := damock.NewDAMock(home).LocalHead()
+	if err == nil {
+		return nil
+	}
+
+	cfg, err := damock.LoadConfig(home)
+	if err != nil {
+		return err
+	}
+
+	srv, err := damock.NewServer(home)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := srv.ServeListener(ctx, ln)
+		if err != nil {
+			pterm.Error.Println("local DA stopped: ", err)
+		}
+	}()
+
+	pterm.Info.Printf("local DA listening on http://%s\n", cfg.Address)
+	return nil
+}
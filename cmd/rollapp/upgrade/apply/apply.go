@@ -0,0 +1,175 @@
+package apply
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/start"
+	"github.com/dymensionxyz/roller/cmd/services/stop"
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/upgrades"
+)
+
+const healthCheckInterval = 5 * time.Second
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <upgrade-name>",
+		Short: "Apply a staged upgrade now",
+		Long: `Apply a staged upgrade now.
+
+The rollapp is stopped, the staged binary activated, the config migrations of the
+version applied and the rollapp started again. When the rollapp doesn't produce
+blocks with the new binary before --timeout, the previous binary is restored.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			var haltHeight int64
+			if h, err := dymint.GetLocalHeight(consts.DefaultRollappRPC); err == nil {
+				haltHeight = h
+			}
+
+			err = Apply(home, rollerData, args[0], haltHeight, timeout)
+			if err != nil {
+				pterm.Error.Println("failed to apply the upgrade:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().Duration("timeout", 5*time.Minute, "time to wait for new blocks before rolling back")
+
+	return cmd
+}
+
+// Apply switches the rollapp to the staged upgrade. The upgrade is rolled back
+// when the rollapp doesn't produce a block above haltHeight before the
+// timeout
+func Apply(
+	home string,
+	rollerData roller.RollappConfig,
+	name string,
+	haltHeight int64,
+	timeout time.Duration,
+) error {
+	vmType := string(rollerData.RollappVMType)
+	services := []string{"rollapp"}
+
+	err := stop.StopSystemdServices(services)
+	if err != nil {
+		return err
+	}
+
+	current, err := upgrades.Current(home)
+	if err != nil {
+		_ = start.StartServices(services)
+		return err
+	}
+
+	u, err := upgrades.Activate(home, name)
+	if err != nil {
+		_ = start.StartServices(services)
+		return err
+	}
+	pterm.Info.Printf("activated %s (%s)\n", u.Name, u.Version)
+
+	err = migrate(home, vmType, current.Version, u)
+	if err == nil {
+		err = start.StartServices(services)
+	}
+	if err == nil {
+		err = waitForBlocks(haltHeight, timeout)
+	}
+	if err == nil {
+		pterm.Success.Printf("rollapp upgraded to %s\n", u.Version)
+		return nil
+	}
+
+	pterm.Warning.Printf("upgrade to %s failed, rolling back: %v\n", u.Name, err)
+	rbErr := Rollback(home, rollerData)
+	if rbErr != nil {
+		return fmt.Errorf("%w, rollback failed: %v", err, rbErr)
+	}
+
+	return err
+}
+
+// Rollback restores the previously active binary and configuration files
+func Rollback(home string, rollerData roller.RollappConfig) error {
+	services := []string{"rollapp"}
+
+	err := stop.StopSystemdServices(services)
+	if err != nil {
+		return err
+	}
+
+	prev, err := upgrades.Rollback(home, string(rollerData.RollappVMType))
+	if err != nil {
+		return err
+	}
+
+	err = upgrades.RecordBinaryVersion(home, string(rollerData.RollappVMType))
+	if err != nil {
+		return err
+	}
+
+	err = start.StartServices(services)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("rolled back to %s (%s)\n", prev.Name, prev.Version)
+	return nil
+}
+
+func migrate(home, vmType, from string, u *upgrades.StagedUpgrade) error {
+	entries, err := upgrades.Migrate(home, vmType, from, u.Version, false)
+	if err != nil {
+		return err
+	}
+	upgrades.PrintEntries(entries)
+
+	return upgrades.RecordBinaryVersion(home, vmType)
+}
+
+func waitForBlocks(haltHeight int64, timeout time.Duration) error {
+	spinner, _ := pterm.DefaultSpinner.Start(
+		fmt.Sprintf("waiting for blocks above height %d", haltHeight),
+	)
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		h, err := dymint.GetLocalHeight(consts.DefaultRollappRPC)
+		if err == nil && h > haltHeight {
+			spinner.Success(fmt.Sprintf("rollapp is at height %d", h))
+			return nil
+		}
+
+		time.Sleep(healthCheckInterval)
+	}
+
+	spinner.Fail("the rollapp didn't produce new blocks")
+	return fmt.Errorf("no blocks above height %d after %s", haltHeight, timeout)
+}
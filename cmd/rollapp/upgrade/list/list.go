@@ -0,0 +1,91 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/upgrades"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the staged upgrades and the scheduled upgrade plan",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			staged, err := upgrades.ListStaged(home)
+			if err != nil {
+				pterm.Error.Println("failed to list the staged upgrades:", err)
+				return
+			}
+
+			if len(staged) == 0 {
+				pterm.Info.Println("no upgrades staged")
+			} else {
+				var current, previous string
+				if u, err := upgrades.Current(home); err == nil {
+					current = u.Name
+				}
+				if u, err := upgrades.Previous(home); err == nil {
+					previous = u.Name
+				}
+
+				td := pterm.TableData{{"Name", "Version", "Height", "Staged at", "Status"}}
+				for _, u := range staged {
+					var status string
+					switch u.Name {
+					case current:
+						status = "active"
+					case previous:
+						status = "previous"
+					}
+
+					var height string
+					if u.Height > 0 {
+						height = strconv.FormatInt(u.Height, 10)
+					}
+
+					td = append(
+						td,
+						[]string{u.Name, u.Version, height, u.StagedAt.Format("2006-01-02 15:04:05"), status},
+					)
+				}
+				_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+			}
+
+			plan, err := upgrades.QueryPlan(consts.DefaultRollappRPC)
+			if err != nil {
+				pterm.Warning.Println("failed to query the upgrade plan:", err)
+				return
+			}
+			if plan == nil {
+				pterm.Info.Println("no upgrade plan scheduled on the rollapp")
+				return
+			}
+
+			pterm.Info.Printf("upgrade %s is scheduled at height %s\n", plan.Name, plan.Height)
+			if _, err := upgrades.GetStaged(home, plan.Name); err != nil {
+				pterm.Warning.Printf(
+					"upgrade %s is not staged, stage it with %s\n",
+					plan.Name,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller rollapp upgrade stage %s", plan.Name),
+				)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+package stage
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/upgrades"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stage <upgrade-name>",
+		Short: "Stage a rollapp binary for an upgrade",
+		Long: `Stage a rollapp binary for an upgrade.
+
+The name has to match the name of the on-chain upgrade plan. The binary is either
+built from the rollapp repository at --version or copied from --binary. On the
+first run, the installed rollapp binary is moved under roller management.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			version, _ := cmd.Flags().GetString("version")
+			binary, _ := cmd.Flags().GetString("binary")
+			height, _ := cmd.Flags().GetInt64("height")
+
+			if version == "" && binary == "" {
+				pterm.Error.Println("either --version or --binary is required")
+				return
+			}
+
+			// older roller homes record the commit of the genesis file
+			baseline := rollerData.RollappBinaryVersion
+			if !upgrades.IsRelease(string(rollerData.RollappVMType), baseline) {
+				baseline = upgrades.InstalledVersion(home, baseline)
+			}
+
+			err = upgrades.Init(home, baseline)
+			if err != nil {
+				pterm.Error.Println("failed to initialize managed upgrades:", err)
+				return
+			}
+
+			var u *upgrades.StagedUpgrade
+			if binary != "" {
+				u, err = upgrades.StageFromBinary(home, args[0], version, binary, height)
+			} else {
+				u, err = upgrades.StageFromSource(
+					home,
+					args[0],
+					version,
+					string(rollerData.RollappVMType),
+					rollerData.Bech32Prefix,
+					height,
+				)
+			}
+			if err != nil {
+				pterm.Error.Println("failed to stage the upgrade:", err)
+				return
+			}
+
+			pterm.Success.Printf("upgrade %s (%s) staged\n", u.Name, u.Version)
+			l, err := upgrades.LoadLedger(home)
+			if err == nil {
+				if l.Baseline == "" {
+					l.Baseline = baseline
+				}
+				pending, err := upgrades.Pending(l, string(rollerData.RollappVMType), u.Version)
+				if err != nil {
+					pterm.Warning.Println("failed to determine the config migrations:", err)
+				}
+				for _, m := range pending {
+					pterm.Info.Printf("config migration %s will be applied with the upgrade\n", m.ID)
+				}
+			}
+
+			pterm.Info.Println("next steps:")
+			pterm.Info.Printf(
+				"run %s to apply it at the upgrade height\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprint("roller rollapp upgrade watch"),
+			)
+		},
+	}
+
+	cmd.Flags().String("version", "", "release tag or commit of the rollapp to build")
+	cmd.Flags().String("binary", "", "path to a prebuilt rollapp binary")
+	cmd.Flags().Int64("height", 0, "upgrade height, when there is no on-chain upgrade plan")
+
+	return cmd
+}
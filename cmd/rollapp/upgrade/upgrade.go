@@ -0,0 +1,32 @@
+package upgrade
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/apply"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/list"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/rollback"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/stage"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/watch"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade [command]",
+		Short: "Commands to stage and apply rollapp binary upgrades",
+		Long: `Commands to stage and apply rollapp binary upgrades.
+
+Staged binaries are kept in versioned directories in ~/.roller/upgrades and the rollapp
+executable points to the active one. Upgrades are applied at the height of the
+on-chain upgrade plan with the same name, either manually with 'apply' or
+automatically with 'watch'.`,
+	}
+
+	cmd.AddCommand(stage.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(apply.Cmd())
+	cmd.AddCommand(watch.Cmd())
+	cmd.AddCommand(rollback.Cmd())
+
+	return cmd
+}
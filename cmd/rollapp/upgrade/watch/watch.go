@@ -0,0 +1,157 @@
+package watch
+
+import (
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/rollapp/upgrade/apply"
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/upgrades"
+)
+
+// target is the next upgrade to apply, the node commits haltHeight and halts
+type target struct {
+	name       string
+	haltHeight int64
+	// onChain upgrades halt the node by themselves, upgrades staged with a
+	// manual height are applied as soon as the height is reached
+	onChain bool
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Apply staged upgrades when the rollapp reaches the upgrade height",
+		Long: `Apply staged upgrades when the rollapp reaches the upgrade height.
+
+The on-chain upgrade plan is polled and, once the rollapp halts at the upgrade height,
+the staged upgrade with the same name is applied. Upgrades staged with --height are
+applied when the rollapp reaches that height. A failed upgrade is rolled back and
+stops the watch.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			interval, _ := cmd.Flags().GetDuration("interval")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			var next *target
+			var lastHeight int64
+			for {
+				rollerData, err := roller.LoadConfig(home)
+				if err != nil {
+					pterm.Error.Println("failed to load roller config file", err)
+					return
+				}
+
+				h, heightErr := dymint.GetLocalHeight(consts.DefaultRollappRPC)
+
+				// the plan can't be queried once the node halted, keep the last one
+				if heightErr == nil {
+					t, err := nextTarget(home)
+					if err != nil {
+						pterm.Warning.Println("failed to retrieve the next upgrade:", err)
+					} else if t != nil && (next == nil || *t != *next) {
+						pterm.Info.Printf(
+							"upgrade %s is pending, the rollapp halts after height %d\n",
+							t.name,
+							t.haltHeight,
+						)
+						next = t
+					}
+				}
+
+				if next != nil && isDue(next, h, heightErr, lastHeight) {
+					pterm.Info.Printf("applying upgrade %s\n", next.name)
+					err = apply.Apply(home, rollerData, next.name, next.haltHeight, timeout)
+					if err != nil {
+						pterm.Error.Println("failed to apply the upgrade:", err)
+						return
+					}
+					next = nil
+				}
+
+				if heightErr == nil {
+					lastHeight = h
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+
+	cmd.Flags().Duration("interval", 10*time.Second, "polling interval")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "time to wait for new blocks before rolling back")
+
+	return cmd
+}
+
+// nextTarget returns the on-chain upgrade plan if it's staged, otherwise the
+// first staged upgrade with a manual height that isn't active yet
+func nextTarget(home string) (*target, error) {
+	plan, err := upgrades.QueryPlan(consts.DefaultRollappRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan != nil {
+		if _, err := upgrades.GetStaged(home, plan.Name); err != nil {
+			pterm.Warning.Printf(
+				"upgrade %s is scheduled at height %s but it isn't staged\n",
+				plan.Name,
+				plan.Height,
+			)
+			return nil, nil
+		}
+
+		hh, err := plan.HaltHeight()
+		if err != nil {
+			return nil, err
+		}
+
+		return &target{name: plan.Name, haltHeight: hh, onChain: true}, nil
+	}
+
+	staged, err := upgrades.ListStaged(home)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := upgrades.Current(home)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range staged {
+		if u.Height > 0 && u.Name != current.Name && u.StagedAt.After(current.StagedAt) {
+			return &target{name: u.Name, haltHeight: u.Height - 1}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isDue reports whether the node reached the halt height of the upgrade. Nodes
+// halted by an on-chain upgrade either stop serving rpc requests or stop
+// producing blocks
+func isDue(t *target, height int64, heightErr error, lastHeight int64) bool {
+	if !t.onChain {
+		return heightErr == nil && height >= t.haltHeight
+	}
+
+	if lastHeight < t.haltHeight {
+		return false
+	}
+
+	return heightErr != nil || height == lastHeight
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/binaries"
 	blockexplorer "github.com/dymensionxyz/roller/cmd/block-explorer"
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/da"
 	da_light_client "github.com/dymensionxyz/roller/cmd/da-light-client"
 	"github.com/dymensionxyz/roller/cmd/eibc"
 	"github.com/dymensionxyz/roller/cmd/observability"
@@ -34,6 +36,7 @@ func Execute() {
 
 func init() {
 	rootCmd.AddCommand(da_light_client.DALightClientCmd())
+	rootCmd.AddCommand(da.Cmd())
 	rootCmd.AddCommand(relayer.Cmd())
 	rootCmd.AddCommand(keys.Cmd())
 	rootCmd.AddCommand(observability.Cmd())
@@ -42,6 +45,7 @@ func init() {
 	rootCmd.AddCommand(blockexplorer.Cmd())
 	rootCmd.AddCommand(version.Cmd())
 	rootCmd.AddCommand(query.Cmd())
+	rootCmd.AddCommand(binaries.Cmd())
 
 	initconfig.AddGlobalFlags(rootCmd)
 }
@@ -175,6 +175,19 @@ func EibcCmd() *cobra.Command {
 	return cmd
 }
 
+// StartServices starts the services using the service manager of the
+// current platform
+func StartServices(services []string) error {
+	switch runtime.GOOS {
+	case "linux":
+		return startSystemdServices(services)
+	case "darwin":
+		return startLaunchctlServices(services)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+}
+
 func startSystemdServices(services []string) error {
 	if runtime.GOOS != "linux" {
 		return fmt.Errorf(
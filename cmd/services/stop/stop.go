@@ -16,7 +16,7 @@ func Cmd(services []string) *cobra.Command {
 		Use:   "stop",
 		Short: "Stop the systemd services relevant to RollApp",
 		Run: func(cmd *cobra.Command, args []string) {
-			err := stopSystemdServices(services)
+			err := StopSystemdServices(services)
 			if err != nil {
 				pterm.Error.Println("failed to restart systemd services:", err)
 				return
@@ -26,7 +26,7 @@ func Cmd(services []string) *cobra.Command {
 	return cmd
 }
 
-func stopSystemdServices(services []string) error {
+func StopSystemdServices(services []string) error {
 	if runtime.GOOS == "linux" {
 		for _, service := range services {
 			err := servicemanager.StopSystemdService(fmt.Sprintf("%s.service", service))
@@ -11,6 +11,5 @@ func Cmd() *cobra.Command {
 	}
 	// cmd.AddCommand(register.Cmd())
 	// cmd.AddCommand(fund_faucet.Cmd())
-	// cmd.AddCommand(claim.Cmd())
 	return cmd
 }
@@ -1,9 +1,12 @@
 package avail
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os/exec"
+	"strconv"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
@@ -19,15 +22,20 @@ const (
 	ConfigFileName            = "avail.toml"
 	mnemonicEntropySize       = 256
 	keyringNetworkID    uint8 = 42
-	DefaultRPCEndpoint        = "wss://goldberg.avail.tools/ws"
+	DefaultRPCEndpoint        = "wss://turing-rpc.avail.so/ws"
 	requiredAVL               = 1
+	availDecimals             = 18
 )
 
+// Avail doesn't run a light client, dymint submits the batches to the rpc
+// endpoint directly with the account derived from the mnemonic
 type Avail struct {
 	Root        string
 	Mnemonic    string
 	AccAddress  string
 	RpcEndpoint string
+	AppID       uint32
+	NetworkID   string
 
 	client *gsrpc.SubstrateAPI
 }
@@ -39,43 +47,82 @@ func (a *Avail) GetPrivateKey() (string, error) {
 func (a *Avail) SetMetricsEndpoint(endpoint string) {
 }
 
+// NewAvail loads the avail configuration of the roller home. The network and
+// the app id are taken from roller.toml when avail is the configured DA
 func NewAvail(root string) *Avail {
-	cfgPath := GetCfgFilePath(root)
-	availConfig, err := loadConfigFromTOML(cfgPath)
+	availConfig, err := loadConfigFromTOML(GetCfgFilePath(root))
 	if err != nil {
-		entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
-		if err != nil {
-			panic(err)
+		availConfig = Avail{
+			RpcEndpoint: DefaultRPCEndpoint,
+			NetworkID:   string(consts.AvailTestnet),
+		}
+	}
+	availConfig.Root = root
+
+	rollerData, err := roller.LoadConfig(root)
+	if err == nil && rollerData.DA.Backend == consts.Avail {
+		if rollerData.DA.RpcUrl != "" {
+			availConfig.RpcEndpoint = rollerData.DA.RpcUrl
 		}
+		if rollerData.DA.ID != "" {
+			availConfig.NetworkID = string(rollerData.DA.ID)
+		}
+		if rollerData.DA.AppID != 0 {
+			availConfig.AppID = rollerData.DA.AppID
+		}
+	}
+
+	if availConfig.Mnemonic != "" {
+		keyringPair, err := signature.KeyringPairFromSecret(
+			availConfig.Mnemonic,
+			keyringNetworkID,
+		)
+		if err == nil {
+			availConfig.AccAddress = keyringPair.Address
+		}
+	}
 
-		availConfig.Mnemonic, err = bip39.NewMnemonic(entropySeed)
+	return &availConfig
+}
+
+// InitializeLightNodeConfig generates the avail account and writes it to the
+// avail configuration, an existing account is kept
+func (a *Avail) InitializeLightNodeConfig() (string, error) {
+	if a.Mnemonic == "" {
+		entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
 		if err != nil {
-			panic(err)
+			return "", err
 		}
 
-		err = writeConfigToTOML(cfgPath, availConfig)
+		a.Mnemonic, err = bip39.NewMnemonic(entropySeed)
 		if err != nil {
-			panic(err)
+			return "", err
 		}
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(availConfig.Mnemonic, keyringNetworkID)
+	keyringPair, err := signature.KeyringPairFromSecret(a.Mnemonic, keyringNetworkID)
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("invalid avail mnemonic: %w", err)
 	}
-	availConfig.AccAddress = keyringPair.Address
+	a.AccAddress = keyringPair.Address
 
-	availConfig.Root = root
-	availConfig.RpcEndpoint = DefaultRPCEndpoint
-	return &availConfig
-}
+	err = writeConfigToTOML(GetCfgFilePath(a.Root), *a)
+	if err != nil {
+		return "", err
+	}
 
-func (a *Avail) InitializeLightNodeConfig() error {
-	return nil
+	return a.Mnemonic, nil
 }
 
-func (a *Avail) GetDAAccountAddress() (string, error) {
-	return a.AccAddress, nil
+func (a *Avail) GetDAAccountAddress() (*keys.KeyInfo, error) {
+	if a.AccAddress == "" {
+		return nil, errors.New("avail account is not initialized")
+	}
+
+	return &keys.KeyInfo{
+		Name:    a.GetKeyName(),
+		Address: a.AccAddress,
+	}, nil
 }
 
 func (a *Avail) CheckDABalance() ([]keys.NotFundedAddressData, error) {
@@ -84,56 +131,58 @@ func (a *Avail) CheckDABalance() ([]keys.NotFundedAddressData, error) {
 		return nil, fmt.Errorf("failed to get DA balance: %w", err)
 	}
 
-	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(availDecimals), nil)
 	required := new(big.Int).Mul(big.NewInt(requiredAVL), exp)
-	if required.Cmp(balance.Int) > 0 {
+	if required.Cmp(balance) > 0 {
 		return []keys.NotFundedAddressData{
 			{
 				KeyName:         a.GetKeyName(),
 				Address:         a.AccAddress,
-				CurrentBalance:  balance.Int,
+				CurrentBalance:  balance,
 				RequiredBalance: required,
 				Denom:           consts.Denoms.Avail,
-				Network:         "avail",
+				Network:         a.GetNetworkName(),
 			},
 		}, nil
 	}
 	return nil, nil
 }
 
-func (a *Avail) getBalance() (availtypes.U128, error) {
+// getBalance returns the free balance of the account, an account that doesn't
+// exist on chain yet has no balance
+func (a *Avail) getBalance() (*big.Int, error) {
 	if a.client == nil {
-		client, err := gsrpc.NewSubstrateAPI(DefaultRPCEndpoint)
+		client, err := gsrpc.NewSubstrateAPI(a.RpcEndpoint)
 		if err != nil {
-			return availtypes.U128{}, err
+			return nil, err
 		}
 		a.client = client
 	}
-	var res availtypes.U128
+
 	meta, err := a.client.RPC.State.GetMetadataLatest()
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 
 	keyringPair, err := signature.KeyringPairFromSecret(a.Mnemonic, keyringNetworkID)
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 	key, err := availtypes.CreateStorageKey(meta, "System", "Account", keyringPair.PublicKey)
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 
 	var accountInfo availtypes.AccountInfo
 	ok, err := a.client.RPC.State.GetStorageLatest(key, &accountInfo)
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 	if !ok {
-		return res, fmt.Errorf("account %s not found", keyringPair.Address)
+		return big.NewInt(0), nil
 	}
 
-	return accountInfo.Data.Free, nil
+	return accountInfo.Data.Free.Int, nil
 }
 
 func (a *Avail) GetStartDACmd() *exec.Cmd {
@@ -150,18 +199,32 @@ func (a *Avail) GetDAAccData(c roller.RollappConfig) ([]keys.AccountData, error)
 			Address: a.AccAddress,
 			Balance: keys.Balance{
 				Denom:  consts.Denoms.Avail,
-				Amount: balance.Int,
+				Amount: balance,
 			},
 		},
 	}, nil
 }
 
-func (a *Avail) GetSequencerDAConfig() string {
-	return fmt.Sprintf(
-		`{"seed": "%s", "api_url": "%s", "app_id": 0, "tip":0}`,
-		a.Mnemonic,
-		a.RpcEndpoint,
-	)
+// GetSequencerDAConfig returns the dymint avail configuration. Full nodes
+// only read from avail, but dymint requires the seed for both node types
+func (a *Avail) GetSequencerDAConfig(nt string) (string, error) {
+	cfg := struct {
+		Seed   string `json:"seed"`
+		ApiUrl string `json:"api_url"`
+		AppID  uint32 `json:"app_id"`
+		Tip    int    `json:"tip"`
+	}{
+		Seed:   a.Mnemonic,
+		ApiUrl: a.RpcEndpoint,
+		AppID:  a.AppID,
+	}
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
 }
 
 func (a *Avail) SetRPCEndpoint(rpc string) {
@@ -173,13 +236,28 @@ func (a *Avail) GetLightNodeEndpoint() string {
 }
 
 func (a *Avail) GetNetworkName() string {
-	return "avail"
+	return a.NetworkID
 }
 
 func (a *Avail) GetStatus(c roller.RollappConfig) string {
+	_, err := a.getBalance()
+	if err != nil {
+		return "Unreachable"
+	}
+
 	return "Active"
 }
 
 func (a *Avail) GetKeyName() string {
 	return "avail"
 }
+
+func (a *Avail) GetRootDirectory() string {
+	return a.Root
+}
+
+// GetNamespaceID returns the avail application id the rollapp data is
+// submitted with
+func (a *Avail) GetNamespaceID() string {
+	return strconv.FormatUint(uint64(a.AppID), 10)
+}
@@ -0,0 +1,197 @@
+package avail_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	availtypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/avail"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const testAppID = 42
+
+// substrateNode is a local stand-in for an avail node, it serves the metadata
+// and the System.Account storage of the funded accounts over json rpc
+type substrateNode struct {
+	accounts map[string]string
+}
+
+func (n *substrateNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params []string        `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result any
+	switch req.Method {
+	case "state_getMetadata":
+		result = availtypes.MetadataV14Data
+	case "state_getStorage":
+		// accounts that don't exist on chain have no storage
+		if v, ok := n.accounts[req.Params[0]]; ok {
+			result = v
+		}
+	default:
+		http.Error(w, "unsupported method "+req.Method, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(
+		map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		},
+	)
+}
+
+// fund sets the free balance of the account derived from the mnemonic
+func (n *substrateNode) fund(t *testing.T, mnemonic string, free *big.Int) {
+	t.Helper()
+
+	var meta availtypes.Metadata
+	require.NoError(t, codec.DecodeFromHex(availtypes.MetadataV14Data, &meta))
+
+	kp, err := signature.KeyringPairFromSecret(mnemonic, 42)
+	require.NoError(t, err)
+	key, err := availtypes.CreateStorageKey(&meta, "System", "Account", kp.PublicKey)
+	require.NoError(t, err)
+
+	var info availtypes.AccountInfo
+	info.Data.Free = availtypes.NewU128(*free)
+	info.Data.Reserved = availtypes.NewU128(*big.NewInt(0))
+	info.Data.MiscFrozen = availtypes.NewU128(*big.NewInt(0))
+	info.Data.FreeFrozen = availtypes.NewU128(*big.NewInt(0))
+	v, err := codec.EncodeToHex(info)
+	require.NoError(t, err)
+
+	n.accounts[key.Hex()] = v
+}
+
+// setupAvailHome writes a roller home using avail on the node and initializes
+// the avail account
+func setupAvailHome(t *testing.T, rpc string) (string, string) {
+	t.Helper()
+
+	home := t.TempDir()
+	require.NoError(
+		t, roller.WriteConfig(
+			roller.RollappConfig{
+				Home:     home,
+				NodeType: consts.NodeType.Sequencer,
+				DA: consts.DaData{
+					Backend: consts.Avail,
+					ID:      consts.AvailLocal,
+					RpcUrl:  rpc,
+					AppID:   testAppID,
+				},
+			},
+		),
+	)
+
+	mnemonic, err := avail.NewAvail(home).InitializeLightNodeConfig()
+	require.NoError(t, err)
+	require.True(t, bip39.IsMnemonicValid(mnemonic))
+
+	return home, mnemonic
+}
+
+func avl(v int64) *big.Int {
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	return new(big.Int).Mul(big.NewInt(v), exp)
+}
+
+func TestGetSequencerDAConfig(t *testing.T) {
+	rpc := "ws://127.0.0.1:9944"
+	home, mnemonic := setupAvailHome(t, rpc)
+
+	var dl datalayer.DataLayer = avail.NewAvail(home)
+	for _, nt := range []string{consts.NodeType.Sequencer, consts.NodeType.FullNode} {
+		var cfg struct {
+			Seed   string `json:"seed"`
+			ApiUrl string `json:"api_url"`
+			AppID  uint32 `json:"app_id"`
+		}
+		daConfig, err := dl.GetSequencerDAConfig(nt)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal([]byte(daConfig), &cfg))
+		require.Equal(t, mnemonic, cfg.Seed)
+		require.Equal(t, rpc, cfg.ApiUrl)
+		require.EqualValues(t, testAppID, cfg.AppID)
+	}
+}
+
+func TestCheckDABalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		balance   *big.Int
+		notFunded bool
+	}{
+		{name: "funded", balance: avl(2)},
+		{name: "required balance", balance: avl(1)},
+		{name: "underfunded", balance: big.NewInt(5), notFunded: true},
+		{name: "unknown account", notFunded: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				node := &substrateNode{accounts: map[string]string{}}
+				srv := httptest.NewServer(node)
+				defer srv.Close()
+
+				home, mnemonic := setupAvailHome(t, srv.URL)
+				if tc.balance != nil {
+					node.fund(t, mnemonic, tc.balance)
+				}
+
+				var dl datalayer.DataLayer = avail.NewAvail(home)
+				addr, err := dl.GetDAAccountAddress()
+				require.NoError(t, err)
+
+				res, err := dl.CheckDABalance()
+				require.NoError(t, err)
+				if !tc.notFunded {
+					require.Empty(t, res)
+					return
+				}
+
+				require.Len(t, res, 1)
+				require.Equal(t, addr.Address, res[0].Address)
+				require.Equal(t, avl(1).String(), res[0].RequiredBalance.String())
+				expected := tc.balance
+				if expected == nil {
+					expected = big.NewInt(0)
+				}
+				require.Equal(t, expected.String(), res[0].CurrentBalance.String())
+				require.Equal(t, consts.Denoms.Avail, res[0].Denom)
+			},
+		)
+	}
+}
+
+func TestCheckDABalanceUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	home, _ := setupAvailHome(t, srv.URL)
+	_, err := avail.NewAvail(home).CheckDABalance()
+	require.Error(t, err)
+}
@@ -1,9 +1,11 @@
 package celestia
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
@@ -113,3 +115,55 @@ func ExtractHeightfromDAPath(input string) (string, error) {
 	}
 	return parts[1], nil
 }
+
+// DAPath is the location of a batch on celestia, as submitted by dymint:
+// celestia|<height>|<index>|<length>|<commitment>|<namespace>|<root>
+type DAPath struct {
+	Client     string
+	Height     uint64
+	Index      int
+	Length     int
+	Commitment []byte
+	Namespace  []byte
+	Root       []byte
+}
+
+// ParseDAPath parses the DA path of a state update
+func ParseDAPath(input string) (*DAPath, error) {
+	parts := strings.Split(input, "|")
+	if len(parts) < 6 {
+		return nil, fmt.Errorf("invalid da path %s, expected at least 6 parts", input)
+	}
+
+	p := &DAPath{Client: parts[0]}
+
+	var err error
+	p.Height, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid da path height %s: %w", parts[1], err)
+	}
+	p.Index, err = strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid da path index %s: %w", parts[2], err)
+	}
+	p.Length, err = strconv.Atoi(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid da path length %s: %w", parts[3], err)
+	}
+	p.Commitment, err = hex.DecodeString(parts[4])
+	if err != nil {
+		return nil, fmt.Errorf("invalid da path commitment %s: %w", parts[4], err)
+	}
+	p.Namespace, err = hex.DecodeString(parts[5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid da path namespace %s: %w", parts[5], err)
+	}
+	if len(parts) > 6 {
+		p.Root, err = hex.DecodeString(parts[6])
+		if err != nil {
+			return nil, fmt.Errorf("invalid da path root %s: %w", parts[6], err)
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,93 @@
+package celestia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDAPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *DAPath
+		err   string
+	}{
+		{
+			name:  "without root",
+			input: "celestia|2405123|7|3|0a0b|0000000000000000000000000000000000000000000001020304050607",
+			want: &DAPath{
+				Client:     "celestia",
+				Height:     2405123,
+				Index:      7,
+				Length:     3,
+				Commitment: []byte{0x0a, 0x0b},
+				Namespace: []byte{
+					0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7,
+				},
+			},
+		},
+		{
+			name:  "with root",
+			input: "celestia|1|0|1|ff|01|abcd",
+			want: &DAPath{
+				Client:     "celestia",
+				Height:     1,
+				Index:      0,
+				Length:     1,
+				Commitment: []byte{0xff},
+				Namespace:  []byte{0x01},
+				Root:       []byte{0xab, 0xcd},
+			},
+		},
+		{
+			name:  "too few parts",
+			input: "celestia|1|0|1|ff",
+			err:   "expected at least 6 parts",
+		},
+		{
+			name:  "invalid height",
+			input: "celestia|-1|0|1|ff|01",
+			err:   "invalid da path height",
+		},
+		{
+			name:  "invalid index",
+			input: "celestia|1|first|1|ff|01",
+			err:   "invalid da path index",
+		},
+		{
+			name:  "invalid length",
+			input: "celestia|1|0||ff|01",
+			err:   "invalid da path length",
+		},
+		{
+			name:  "invalid commitment",
+			input: "celestia|1|0|1|zz|01",
+			err:   "invalid da path commitment",
+		},
+		{
+			name:  "invalid namespace",
+			input: "celestia|1|0|1|ff|0",
+			err:   "invalid da path namespace",
+		},
+		{
+			name:  "invalid root",
+			input: "celestia|1|0|1|ff|01|xyz",
+			err:   "invalid da path root",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				p, err := ParseDAPath(tc.input)
+				if tc.err != "" {
+					require.ErrorContains(t, err, tc.err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.want, p)
+			},
+		)
+	}
+}
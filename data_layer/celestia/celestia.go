@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"math/big"
 	"os/exec"
@@ -296,9 +295,26 @@ func (c *Celestia) GetNetworkName() string {
 }
 
 func (c *Celestia) GetNamespaceID() string {
+	if c.NamespaceID == "" {
+		raCfg, err := roller.LoadConfig(c.Root)
+		if err == nil {
+			c.NamespaceID = raCfg.DA.NamespaceID
+		}
+	}
+
 	return c.NamespaceID
 }
 
+// GetAuthToken returns a light node auth token with the given permission
+func (c *Celestia) GetAuthToken(t string) (string, error) {
+	raCfg, err := roller.LoadConfig(c.Root)
+	if err != nil {
+		return "", err
+	}
+
+	return c.getAuthToken(t, raCfg)
+}
+
 func (c *Celestia) getAuthToken(t string, raCfg roller.RollappConfig) (string, error) {
 	getAuthTokenCmd := exec.Command(
 		consts.Executables.Celestia,
@@ -317,10 +333,7 @@ func (c *Celestia) getAuthToken(t string, raCfg roller.RollappConfig) (string, e
 	return strings.TrimSuffix(output.String(), "\n"), nil
 }
 
-func (c *Celestia) GetSequencerDAConfig(nt string) string {
-	if c.NamespaceID == "" {
-		c.NamespaceID = generateRandNamespaceID()
-	}
+func (c *Celestia) GetSequencerDAConfig(nt string) (string, error) {
 	lcEndpoint := c.GetLightNodeEndpoint()
 
 	var authToken string
@@ -328,7 +341,26 @@ func (c *Celestia) GetSequencerDAConfig(nt string) string {
 
 	raCfg, err := roller.LoadConfig(c.Root)
 	if err != nil {
-		return ""
+		return "", err
+	}
+
+	if c.NamespaceID == "" {
+		c.NamespaceID = raCfg.DA.NamespaceID
+	}
+	// only the sequencer picks a namespace, full nodes have to read from the
+	// one the sequencer posts to
+	if c.NamespaceID == "" && nt == consts.NodeType.Sequencer {
+		namespaceID := generateRandNamespaceID()
+		err = tomlconfig.UpdateFieldInFile(
+			roller.GetConfigPath(c.Root),
+			"DA.namespace_id",
+			namespaceID,
+		)
+		if err != nil {
+			// an unpersisted namespace would change on the next run
+			return "", fmt.Errorf("failed to persist the celestia namespace: %w", err)
+		}
+		c.NamespaceID = namespaceID
 	}
 
 	if nt == consts.NodeType.Sequencer {
@@ -336,13 +368,11 @@ func (c *Celestia) GetSequencerDAConfig(nt string) string {
 	} else if nt == consts.NodeType.FullNode {
 		authToken, err = c.getAuthToken(consts.DaAuthTokenType.Read, raCfg)
 	} else {
-		// TODO: don't panic,return an err
-		err := errors.New("invalid node type")
-		panic(err)
+		return "", fmt.Errorf("invalid node type %q", nt)
 	}
 
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("failed to retrieve the DA auth token: %w", err)
 	}
 
 	return fmt.Sprintf(
@@ -350,5 +380,5 @@ func (c *Celestia) GetSequencerDAConfig(nt string) string {
 		lcEndpoint,
 		c.NamespaceID,
 		authToken,
-	)
+	), nil
 }
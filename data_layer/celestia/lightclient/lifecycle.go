@@ -0,0 +1,253 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	// TrustedFromLatest starts syncing at the latest celestia block, enough
+	// for a sequencer that only submits new batches
+	TrustedFromLatest = "latest"
+	// TrustedFromFirstState starts syncing at the celestia block of the first
+	// state update, full nodes need it to retrieve the rollapp history
+	TrustedFromFirstState = "first-state"
+)
+
+// preservedStoreEntries are kept when the light node store is reset, the keys
+// must survive because the DA account is registered with the rollapp
+var preservedStoreEntries = []string{consts.KeysDirName, "config.toml"}
+
+// SyncInfo compares the head of the light node with the celestia network
+type SyncInfo struct {
+	LocalHead    uint64
+	NetworkHead  uint64
+	SampledHead  uint64
+	CatchUpDone  bool
+	SyncFrom     uint64
+	SyncTo       uint64
+	SyncFinished bool
+}
+
+func storeDir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.DALightNode)
+}
+
+func ConfigFilePath(home string) string {
+	return filepath.Join(storeDir(home), "config.toml")
+}
+
+// IsRunning reports whether the light node RPC endpoint accepts connections
+func IsRunning(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, 2*time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+
+	return true
+}
+
+// ResetStore removes the headers, samples and blocks of the light node store,
+// the keys and the configuration are preserved. The removed entries are
+// returned
+func ResetStore(home string) ([]string, error) {
+	entries, err := os.ReadDir(storeDir(home))
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, e := range entries {
+		if slices.Contains(preservedStoreEntries, e.Name()) {
+			continue
+		}
+
+		err := os.RemoveAll(filepath.Join(storeDir(home), e.Name()))
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, e.Name())
+	}
+
+	return removed, nil
+}
+
+// TrustedBlock returns the celestia block the light node starts syncing from
+func TrustedBlock(rollerData roller.RollappConfig, from string) (int, string, error) {
+	var height, hash string
+	var err error
+
+	switch from {
+	case TrustedFromLatest:
+		height, hash, err = celestia.GetLatestBlock(rollerData)
+	case TrustedFromFirstState:
+		var si *rollapp.StateInfo
+		si, err = rollapp.GetStateInfo(rollerData.RollappID, "1", rollerData.HubData)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to retrieve the first state update: %w", err)
+		}
+
+		var daHeight string
+		daHeight, err = celestia.ExtractHeightfromDAPath(si.DAPath)
+		if err != nil {
+			return 0, "", err
+		}
+		height, hash, err = celestia.GetBlockByHeight(daHeight, rollerData)
+	default:
+		return 0, "", fmt.Errorf(
+			"invalid trusted block source %s, expected %s or %s",
+			from,
+			TrustedFromLatest,
+			TrustedFromFirstState,
+		)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	h, err := strconv.Atoi(height)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid celestia height %s: %w", height, err)
+	}
+	if hash == "" {
+		return 0, "", fmt.Errorf("no block hash found for celestia height %d", h)
+	}
+
+	return h, hash, nil
+}
+
+// Resync resets the light node store and makes it sync from the given
+// trusted block
+func Resync(home string, height int, hash string) error {
+	_, err := ResetStore(home)
+	if err != nil {
+		return err
+	}
+
+	return UpdateConfig(ConfigFilePath(home), strings.ToUpper(hash), height)
+}
+
+// SetPruning enables or disables the pruning of the samples and blocks that
+// are outside of the sampling window
+func SetPruning(home string, enabled bool) error {
+	return tomlconfig.UpdateFieldInFile(ConfigFilePath(home), "Pruner.EnableService", enabled)
+}
+
+// SetSampleFrom sets the first celestia height the light node samples, blocks
+// below it are neither sampled nor stored
+func SetSampleFrom(home string, height int) error {
+	return tomlconfig.UpdateFieldInFile(ConfigFilePath(home), "DASer.SampleFrom", int64(height))
+}
+
+// StoreConfig returns the sync related settings of the light node config
+func StoreConfig(home string) (trustedHash string, sampleFrom string, pruning string, err error) {
+	cfg, err := toml.LoadFile(ConfigFilePath(home))
+	if err != nil {
+		return "", "", "", err
+	}
+
+	value := func(key string) string {
+		v := cfg.Get(key)
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+
+	return value("Header.TrustedHash"), value("DASer.SampleFrom"), value("Pruner.EnableService"), nil
+}
+
+// StoreSize returns the disk usage of the light node store
+func StoreSize(home string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(
+		storeDir(home), func(_ string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			fi, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			size += fi.Size()
+			return nil
+		},
+	)
+
+	return size, err
+}
+
+// GetSyncInfo queries the sync state of the running light node
+func GetSyncInfo(client *celestia.NodeClient) (*SyncInfo, error) {
+	var err error
+	si := &SyncInfo{}
+	si.LocalHead, err = client.LocalHead()
+	if err != nil {
+		return nil, err
+	}
+
+	var head struct {
+		Header struct {
+			Height string `json:"height"`
+		} `json:"header"`
+	}
+	err = client.Call("header.NetworkHead", nil, &head)
+	if err != nil {
+		return nil, err
+	}
+	si.NetworkHead, _ = strconv.ParseUint(head.Header.Height, 10, 64)
+
+	var state struct {
+		FromHeight uint64 `json:"from_height"`
+		ToHeight   uint64 `json:"to_height"`
+		Height     uint64 `json:"height"`
+	}
+	err = client.Call("header.SyncState", nil, &state)
+	if err == nil {
+		si.SyncFrom = state.FromHeight
+		si.SyncTo = state.ToHeight
+		si.SyncFinished = state.Height >= state.ToHeight
+	}
+
+	var stats struct {
+		HeadOfSampledChain uint64 `json:"head_of_sampled_chain"`
+		CatchUpDone        bool   `json:"catch_up_done"`
+	}
+	err = client.Call("das.SamplingStats", nil, &stats)
+	if err == nil {
+		si.SampledHead = stats.HeadOfSampledChain
+		si.CatchUpDone = stats.CatchUpDone
+	}
+
+	return si, nil
+}
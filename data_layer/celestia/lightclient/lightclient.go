@@ -28,7 +28,10 @@ func Initialize(env string, rollerData roller.RollappConfig) (*keys.KeyInfo, err
 		hd := rollerData.HubData
 		raID := rollerData.RollappID
 
-		damanager := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+		damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+		if err != nil {
+			return nil, err
+		}
 		mnemonic, err := damanager.InitializeLightNodeConfig()
 		if err != nil {
 			return nil, err
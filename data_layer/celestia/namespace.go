@@ -0,0 +1,130 @@
+package celestia
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// A celestia namespace is a version byte followed by a 28 byte id, dymint
+// uses version 0 namespaces whose id is 18 zero bytes followed by the 10 byte
+// namespace id of the rollapp
+const (
+	namespaceIDSize = 10
+	namespaceSize   = 29
+)
+
+// ValidateNamespaceID checks that the namespace id is a hex encoded 10 byte
+// id outside of the range celestia reserves for itself
+func ValidateNamespaceID(id string) error {
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return fmt.Errorf("namespace id %s is not hex encoded", id)
+	}
+	if len(b) != namespaceIDSize {
+		return fmt.Errorf(
+			"namespace id %s has %d bytes, expected %d (%d hex characters)",
+			id,
+			len(b),
+			namespaceIDSize,
+			namespaceIDSize*2,
+		)
+	}
+	if bytes.Equal(b[:namespaceIDSize-1], make([]byte, namespaceIDSize-1)) {
+		return fmt.Errorf("namespace id %s is reserved by celestia", id)
+	}
+
+	return nil
+}
+
+// NamespaceBytes returns the version 0 namespace of the namespace id, as
+// used by the celestia node API
+func NamespaceBytes(id string) ([]byte, error) {
+	err := ValidateNamespaceID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := hex.DecodeString(id)
+	return append(make([]byte, namespaceSize-namespaceIDSize), b...), nil
+}
+
+// NamespaceIDFromBytes returns the namespace id of a version 0 namespace
+func NamespaceIDFromBytes(ns []byte) (string, error) {
+	switch len(ns) {
+	case namespaceIDSize:
+	case namespaceSize:
+		if ns[0] != 0 {
+			return "", fmt.Errorf("unsupported namespace version %d", ns[0])
+		}
+		ns = ns[namespaceSize-namespaceIDSize:]
+	default:
+		return "", fmt.Errorf("invalid namespace length %d", len(ns))
+	}
+
+	id := hex.EncodeToString(ns)
+	return id, ValidateNamespaceID(id)
+}
+
+// NamespaceIDFromHub returns the namespace id of the latest batch the
+// sequencer posted to celestia, taken from the DA path of the state update
+func NamespaceIDFromHub(rollerData roller.RollappConfig) (string, error) {
+	if rollerData.HubData.ID == consts.MockHubID {
+		return "", errors.New("the mock hub doesn't have state updates")
+	}
+
+	si, err := rollapp.GetStateInfo(rollerData.RollappID, "", rollerData.HubData)
+	if err != nil {
+		if strings.Contains(err.Error(), "NotFound") {
+			return "", errors.New("the rollapp didn't post a state update yet")
+		}
+		return "", err
+	}
+
+	p, err := ParseDAPath(si.DAPath)
+	if err != nil {
+		return "", err
+	}
+	if p.Client != string(consts.Celestia) {
+		return "", fmt.Errorf("the latest state update was posted to %s", p.Client)
+	}
+
+	return NamespaceIDFromBytes(p.Namespace)
+}
+
+// SetNamespaceID records the namespace id in roller.toml and points dymint
+// at it, the rollapp has to be restarted to use it
+func SetNamespaceID(home, id string) error {
+	err := ValidateNamespaceID(id)
+	if err != nil {
+		return err
+	}
+
+	err = tomlconfig.UpdateFieldInFile(roller.GetConfigPath(home), "DA.namespace_id", id)
+	if err != nil {
+		return err
+	}
+
+	err = tomlconfig.UpdateFieldInFile(sequencer.GetDymintFilePath(home), "namespace_id", id)
+	if err != nil {
+		return err
+	}
+
+	daCfg, err := sequencer.GetDAConfig(home)
+	if err != nil {
+		return err
+	}
+	if _, ok := daCfg["namespace_id"]; ok {
+		return sequencer.SetDAConfigField(home, "namespace_id", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package celestia
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+const nodeRPCTimeout = 30 * time.Second
+
+// ErrUnauthorized is returned when the node rejects the auth token
+var ErrUnauthorized = errors.New("the node rejected the auth token")
+
+// NodeClient calls the JSON-RPC API of a celestia node, the light client
+// and the local DA both serve it
+type NodeClient struct {
+	Endpoint  string
+	AuthToken string
+}
+
+type Blob struct {
+	Namespace    []byte `json:"namespace"`
+	Data         []byte `json:"data"`
+	ShareVersion uint32 `json:"share_version"`
+	Commitment   []byte `json:"commitment"`
+	Index        int    `json:"index"`
+}
+
+// NewNodeClientFromDymint returns a client for the DA node dymint submits to,
+// using the endpoint and the auth token of the dymint da_config
+func NewNodeClientFromDymint(home string) (*NodeClient, error) {
+	daConfig, err := tomlconfig.GetKeyFromFile(sequencer.GetDymintFilePath(home), "da_config")
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		BaseURL   string `json:"base_url"`
+		AuthToken string `json:"auth_token"`
+	}
+	err = json.Unmarshal([]byte(daConfig), &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dymint da_config: %w", err)
+	}
+	if cfg.BaseURL == "" {
+		return nil, errors.New("dymint da_config doesn't have a base_url")
+	}
+
+	return &NodeClient{Endpoint: cfg.BaseURL, AuthToken: cfg.AuthToken}, nil
+}
+
+func (c *NodeClient) Call(method string, params []any, out any) error {
+	if params == nil {
+		params = []any{}
+	}
+	b, err := json.Marshal(
+		map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+			"params":  params,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.Endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+
+	resp, err := (&http.Client{Timeout: nodeRPCTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&rpcResp)
+	if err != nil {
+		return fmt.Errorf("invalid %s response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s failed: %s", method, rpcResp.Error.Message)
+	}
+
+	return json.Unmarshal(rpcResp.Result, out)
+}
+
+// GetBlob fetches the blob with the given commitment from the DA height
+func (c *NodeClient) GetBlob(height uint64, namespace, commitment []byte) (*Blob, error) {
+	var blob Blob
+	err := c.Call("blob.Get", []any{height, namespace, commitment}, &blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blob, nil
+}
+
+// GetAll returns the blobs of the namespace at the DA height, heights
+// without blobs return none
+func (c *NodeClient) GetAll(height uint64, namespace []byte) ([]Blob, error) {
+	var blobs []Blob
+	err := c.Call("blob.GetAll", []any{height, [][]byte{namespace}}, &blobs)
+	if err != nil {
+		if strings.Contains(err.Error(), "blob: not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+// LocalHead returns the latest height the node synced
+func (c *NodeClient) LocalHead() (uint64, error) {
+	var head struct {
+		Header struct {
+			Height string `json:"height"`
+		} `json:"header"`
+	}
+	err := c.Call("header.LocalHead", nil, &head)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(head.Header.Height, 10, 64)
+}
@@ -1,11 +1,14 @@
 package datalayer
 
 import (
+	"fmt"
 	"os/exec"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/data_layer/avail"
 	"github.com/dymensionxyz/roller/data_layer/celestia"
 	"github.com/dymensionxyz/roller/data_layer/damock"
+	"github.com/dymensionxyz/roller/data_layer/plugin"
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
@@ -18,7 +21,7 @@ type DataLayer interface {
 	GetDAAccData(c roller.RollappConfig) ([]keys.AccountData, error)
 	GetLightNodeEndpoint() string
 	// todo: Refactor, node type makes reusability awful
-	GetSequencerDAConfig(nt string) string
+	GetSequencerDAConfig(nt string) (string, error)
 	SetRPCEndpoint(string)
 	SetMetricsEndpoint(endpoint string)
 	GetNetworkName() string
@@ -29,27 +32,44 @@ type DataLayer interface {
 	GetNamespaceID() string
 }
 
+var (
+	_ DataLayer = (*celestia.Celestia)(nil)
+	_ DataLayer = (*avail.Avail)(nil)
+	_ DataLayer = (*damock.DAMock)(nil)
+	_ DataLayer = (*plugin.Plugin)(nil)
+)
+
 type DAManager struct {
 	datype consts.DAType
 	DataLayer
 }
 
-func NewDAManager(datype consts.DAType, home string) *DAManager {
+// NewDAManager returns the manager of the DA backend. Backends other than the
+// built-in ones require an installed plugin
+func NewDAManager(datype consts.DAType, home string) (*DAManager, error) {
 	var dalayer DataLayer
 
 	switch datype {
 	case consts.Celestia:
 		dalayer = celestia.NewCelestia(home)
-	// case config.Avail:
-	// 	dalayer = avail.NewAvail(home)
+	case consts.Avail:
+		dalayer = avail.NewAvail(home)
 	case consts.Local:
-		dalayer = &damock.DAMock{}
+		dalayer = damock.NewDAMock(home)
 	default:
-		panic("Unknown data layer type")
+		// any other DA is provided by an external plugin
+		if !roller.IsDAPluginInstalled(home, string(datype)) {
+			return nil, fmt.Errorf(
+				"unknown data layer type %s, no plugin is installed at %s",
+				datype,
+				roller.DAPluginPath(home, string(datype)),
+			)
+		}
+		dalayer = plugin.NewPlugin(home, string(datype))
 	}
 
 	return &DAManager{
 		datype:    datype,
 		DataLayer: dalayer,
-	}
+	}, nil
 }
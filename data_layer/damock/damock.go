@@ -1,13 +1,35 @@
 package damock
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
 	"os/exec"
+	"time"
 
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
-type DAMock struct{}
+const (
+	keyName        = "local-da"
+	localDAAddress = "local-da"
+	clientTimeout  = 5 * time.Second
+)
+
+// DAMock is the local DA, it's served by `roller da local start` and speaks
+// the celestia node API so dymint submits to it like to a light client
+type DAMock struct {
+	Root string
+}
+
+func NewDAMock(home string) *DAMock {
+	return &DAMock{Root: home}
+}
 
 func (d *DAMock) GetPrivateKey() (string, error) {
 	return "", nil
@@ -17,27 +39,50 @@ func (d *DAMock) SetMetricsEndpoint(endpoint string) {
 }
 
 func (d *DAMock) GetStatus(c roller.RollappConfig) string {
-	return "Running local DA"
+	h, err := d.LocalHead()
+	if err != nil {
+		return "Stopped, run `roller da local start`"
+	}
+
+	return "Running local DA, height " + h.Header.Height
+}
+
+// LocalHead returns the latest header of the running local DA
+func (d *DAMock) LocalHead() (*ExtendedHeader, error) {
+	var h ExtendedHeader
+	err := d.call("header.LocalHead", nil, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &h, nil
 }
 
 func (d *DAMock) GetRootDirectory() string {
-	return ""
+	return d.Root
 }
 
 func (d *DAMock) GetNamespaceID() string {
-	return ""
-}
+	cfg, err := LoadConfig(d.Root)
+	if err != nil {
+		return ""
+	}
 
-func NewDAMock() *DAMock {
-	return &DAMock{}
+	return cfg.NamespaceID
 }
 
 func (d *DAMock) GetDAAccountAddress() (*keys.KeyInfo, error) {
-	return nil, nil
+	return &keys.KeyInfo{
+		Name:    keyName,
+		Address: localDAAddress,
+	}, nil
 }
 
+// InitializeLightNodeConfig generates the namespace and the auth tokens of
+// the local DA, the local DA doesn't have an account to fund
 func (d *DAMock) InitializeLightNodeConfig() (string, error) {
-	return "", nil
+	_, err := LoadConfig(d.Root)
+	return "", err
 }
 
 func (d *DAMock) CheckDABalance() ([]keys.NotFundedAddressData, error) {
@@ -45,28 +90,125 @@ func (d *DAMock) CheckDABalance() ([]keys.NotFundedAddressData, error) {
 }
 
 func (d *DAMock) GetStartDACmd() *exec.Cmd {
-	return nil
+	return exec.Command(
+		consts.Executables.Roller,
+		"da", "local", "start",
+		"--home", d.Root,
+	)
 }
 
 func (d *DAMock) GetDAAccData(c roller.RollappConfig) ([]keys.AccountData, error) {
-	return []keys.AccountData{}, nil
+	var b balance
+	err := d.call("state.Balance", nil, &b)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, ok := new(big.Int).SetString(b.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid local DA balance %s", b.Amount)
+	}
+
+	return []keys.AccountData{
+		{
+			Address: localDAAddress,
+			Balance: keys.Balance{
+				Denom:  b.Denom,
+				Amount: amount,
+			},
+		},
+	}, nil
 }
 
 func (d *DAMock) GetLightNodeEndpoint() string {
-	return ""
+	cfg, err := LoadConfig(d.Root)
+	if err != nil {
+		return "http://" + DefaultAddress
+	}
+
+	return "http://" + cfg.Address
 }
 
-func (d *DAMock) GetSequencerDAConfig(nt string) string {
-	return ""
+// GetSequencerDAConfig returns the dymint celestia configuration pointing at
+// the local DA
+func (d *DAMock) GetSequencerDAConfig(nt string) (string, error) {
+	cfg, err := LoadConfig(d.Root)
+	if err != nil {
+		return "", err
+	}
+
+	// the mock hub only runs sequencers
+	authToken := cfg.AdminToken
+	if nt == consts.NodeType.FullNode {
+		authToken = cfg.ReadToken
+	}
+
+	return fmt.Sprintf(
+		`{"base_url": "http://%s", "timeout": 60000000000, "gas_prices":0.02, "gas_adjustment": 1.3, "namespace_id":"%s","auth_token":"%s","backoff":{"initial_delay":1000000000,"max_delay":6000000000,"growth_factor":2},"retry_attempts":4,"retry_delay":1000000000}`,
+		cfg.Address,
+		cfg.NamespaceID,
+		authToken,
+	), nil
 }
 
 func (d *DAMock) SetRPCEndpoint(string) {
 }
 
 func (d *DAMock) GetKeyName() string {
-	return ""
+	return keyName
 }
 
 func (d *DAMock) GetNetworkName() string {
 	return "local"
 }
+
+// call runs a read method against the local DA server
+func (d *DAMock) call(method string, params []any, out any) error {
+	cfg, err := LoadConfig(d.Root)
+	if err != nil {
+		return err
+	}
+
+	if params == nil {
+		params = []any{}
+	}
+	b, err := json.Marshal(
+		map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+			"params":  params,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Address, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.ReadToken)
+
+	resp, err := (&http.Client{Timeout: clientTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&rpcResp)
+	if err != nil {
+		return err
+	}
+	if rpcResp.Error != nil {
+		return errors.New(rpcResp.Error.Message)
+	}
+
+	return json.Unmarshal(rpcResp.Result, out)
+}
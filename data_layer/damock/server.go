@@ -0,0 +1,302 @@
+package damock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server is a local DA speaking the subset of the celestia node JSON-RPC API
+// used by dymint, so a rollapp can run end to end without network access
+type Server struct {
+	cfg   *Config
+	store *Store
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// ExtendedHeader is the subset of the celestia header returned by the header
+// methods
+type ExtendedHeader struct {
+	Header struct {
+		ChainID string    `json:"chain_id"`
+		Height  string    `json:"height"`
+		Time    time.Time `json:"time"`
+	} `json:"header"`
+	DAH struct {
+		RowRoots    []string `json:"row_roots"`
+		ColumnRoots []string `json:"column_roots"`
+	} `json:"dah"`
+}
+
+type balance struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+const (
+	permRead  = "read"
+	permAdmin = "admin"
+
+	localBalance = "1000000000000000"
+)
+
+// readMethods can be called with the read token, every other method requires
+// the admin token
+var readMethods = map[string]bool{
+	"blob.Get":           true,
+	"blob.GetAll":        true,
+	"blob.GetProof":      true,
+	"blob.Included":      true,
+	"header.LocalHead":   true,
+	"header.NetworkHead": true,
+	"header.GetByHeight": true,
+	"state.Balance":      true,
+	"node.Info":          true,
+}
+
+func NewServer(home string) (*Server, error) {
+	cfg, err := LoadConfig(home)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := NewStore(home)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{cfg: cfg, store: store}, nil
+}
+
+// Serve runs the server on addr until the context is cancelled
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.ServeListener(ctx, ln)
+}
+
+// ServeListener runs the server on ln until the context is cancelled
+func (s *Server) ServeListener(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err := srv.Serve(ln)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rpcRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeResponse(w, rpcResponse{Error: &rpcError{Code: -32700, Message: err.Error()}})
+		return
+	}
+
+	resp := rpcResponse{ID: req.ID}
+
+	perm := s.permission(r.Header.Get("Authorization"))
+	if perm == "" || (perm != permAdmin && !readMethods[req.Method]) {
+		resp.Error = &rpcError{Code: 401, Message: "missing permission to call " + req.Method}
+		writeResponse(w, resp)
+		return
+	}
+
+	resp.Result, err = s.handle(req.Method, req.Params)
+	if err != nil {
+		resp.Result = nil
+		resp.Error = &rpcError{Code: 1, Message: err.Error()}
+	}
+
+	writeResponse(w, resp)
+}
+
+func (s *Server) permission(authorization string) string {
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	switch token {
+	case s.cfg.AdminToken:
+		return permAdmin
+	case s.cfg.ReadToken:
+		return permRead
+	}
+
+	return ""
+}
+
+func (s *Server) handle(method string, params []json.RawMessage) (any, error) {
+	switch method {
+	case "blob.Submit":
+		var blobs []Blob
+		err := parseParams(params, &blobs)
+		if err != nil {
+			return nil, err
+		}
+		return s.store.Submit(blobs)
+	case "blob.Get":
+		var height uint64
+		var namespace, commitment []byte
+		err := parseParams(params, &height, &namespace, &commitment)
+		if err != nil {
+			return nil, err
+		}
+		return s.store.Get(height, namespace, commitment)
+	case "blob.GetAll":
+		var height uint64
+		var namespaces [][]byte
+		err := parseParams(params, &height, &namespaces)
+		if err != nil {
+			return nil, err
+		}
+		return s.store.GetAll(height, namespaces)
+	case "blob.GetProof":
+		var height uint64
+		var namespace, commitment []byte
+		err := parseParams(params, &height, &namespace, &commitment)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.store.Get(height, namespace, commitment)
+		if err != nil {
+			return nil, err
+		}
+		// the local DA doesn't build share proofs
+		return []any{}, nil
+	case "blob.Included":
+		var height uint64
+		var namespace, proof, commitment json.RawMessage
+		err := parseParams(params, &height, &namespace, &proof, &commitment)
+		if err != nil {
+			return nil, err
+		}
+		var ns, c []byte
+		err = parseParams([]json.RawMessage{namespace, commitment}, &ns, &c)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.store.Get(height, ns, c)
+		return err == nil, nil
+	case "header.LocalHead", "header.NetworkHead":
+		return s.header(s.store.Height())
+	case "header.GetByHeight":
+		var height uint64
+		err := parseParams(params, &height)
+		if err != nil {
+			return nil, err
+		}
+		return s.header(height)
+	case "state.Balance":
+		return balance{Denom: "utia", Amount: localBalance}, nil
+	case "node.Info":
+		return map[string]any{"type": 2, "api_version": "local"}, nil
+	case "node.AuthNew":
+		var perms []string
+		err := parseParams(params, &perms)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range perms {
+			if p == permAdmin {
+				return s.cfg.AdminToken, nil
+			}
+		}
+		return s.cfg.ReadToken, nil
+	}
+
+	return nil, fmt.Errorf("method %s is not supported by the local DA", method)
+}
+
+func (s *Server) header(height uint64) (*ExtendedHeader, error) {
+	var h ExtendedHeader
+	h.Header.ChainID = localDAChainID
+	h.Header.Height = strconv.FormatUint(height, 10)
+	h.DAH.RowRoots = []string{}
+	h.DAH.ColumnRoots = []string{}
+
+	if height == 0 {
+		h.Header.Time = time.Now().UTC()
+		return &h, nil
+	}
+
+	b, err := s.store.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	h.Header.Time = b.Time
+
+	return &h, nil
+}
+
+// parseParams decodes the positional params, trailing params that aren't
+// requested (e.g. the submit options) are ignored
+func parseParams(params []json.RawMessage, out ...any) error {
+	if len(params) < len(out) {
+		return fmt.Errorf("expected %d params, got %d", len(out), len(params))
+	}
+
+	for i, o := range out {
+		// heights are sent as numbers or strings depending on the client
+		if h, ok := o.(*uint64); ok {
+			s := strings.Trim(string(params[i]), `"`)
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %s: %w", s, err)
+			}
+			*h = v
+			continue
+		}
+
+		err := json.Unmarshal(params[i], o)
+		if err != nil {
+			return fmt.Errorf("invalid param %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, resp rpcResponse) {
+	resp.JSONRPC = "2.0"
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,301 @@
+package damock
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+// The local DA keeps its state in the DA light node directory of the roller
+// home, every submission is stored as a block:
+//
+//	<roller_home>/da-light-node/local-da/config.json
+//	<roller_home>/da-light-node/local-da/blocks/<height>.json
+const (
+	localDADirName   = "local-da"
+	configFileName   = "config.json"
+	blocksDirName    = "blocks"
+	DefaultAddress   = "127.0.0.1:26658"
+	localDAChainID   = "local-da"
+	namespaceVersion = 0
+	namespaceSize    = 29
+)
+
+var ErrBlobNotFound = errors.New("blob: not found")
+
+// Config is the local DA configuration, the tokens authorize the RPC calls
+// with admin and read permissions respectively
+type Config struct {
+	Address     string `json:"address"`
+	NamespaceID string `json:"namespace_id"`
+	AdminToken  string `json:"admin_token"`
+	ReadToken   string `json:"read_token"`
+}
+
+// Blob mirrors the celestia node blob encoding
+type Blob struct {
+	Namespace    []byte `json:"namespace"`
+	Data         []byte `json:"data"`
+	ShareVersion uint32 `json:"share_version"`
+	Commitment   []byte `json:"commitment"`
+	Index        int    `json:"index"`
+}
+
+type Block struct {
+	Height uint64    `json:"height"`
+	Time   time.Time `json:"time"`
+	Blobs  []Blob    `json:"blobs"`
+}
+
+// Store is the on-disk block store of the local DA
+type Store struct {
+	root string
+
+	mu     sync.RWMutex
+	height uint64
+}
+
+func LocalDADir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.DALightNode, localDADirName)
+}
+
+// LoadConfig returns the local DA configuration of the roller home, the
+// configuration is generated when it doesn't exist yet
+func LoadConfig(home string) (*Config, error) {
+	p := filepath.Join(LocalDADir(home), configFileName)
+
+	b, err := os.ReadFile(p)
+	if err == nil {
+		var cfg Config
+		err = json.Unmarshal(b, &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local DA config %s: %w", p, err)
+		}
+		return &cfg, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	cfg := &Config{Address: DefaultAddress}
+	cfg.NamespaceID, err = randHex(10)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AdminToken, err = randHex(32)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ReadToken, err = randHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(LocalDADir(home), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(p, b, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// NewStore opens the block store of the roller home, the head is the highest
+// block on disk
+func NewStore(home string) (*Store, error) {
+	root := filepath.Join(LocalDADir(home), blocksDirName)
+	err := os.MkdirAll(root, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{root: root}
+	for _, e := range entries {
+		h, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+		if h > s.height {
+			s.height = h
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Store) Height() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.height
+}
+
+// Submit stores the blobs in a new block and returns its height
+func (s *Store) Submit(blobs []Blob) (uint64, error) {
+	for i := range blobs {
+		if len(blobs[i].Namespace) != namespaceSize {
+			return 0, fmt.Errorf(
+				"invalid namespace size %d, expected %d",
+				len(blobs[i].Namespace),
+				namespaceSize,
+			)
+		}
+		blobs[i].Commitment = commitment(blobs[i].Namespace, blobs[i].Data)
+		blobs[i].Index = i
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := Block{
+		Height: s.height + 1,
+		Time:   time.Now().UTC(),
+		Blobs:  blobs,
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp := s.blockPath(b.Height) + ".tmp"
+	err = os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	err = os.Rename(tmp, s.blockPath(b.Height))
+	if err != nil {
+		return 0, err
+	}
+
+	s.height = b.Height
+	return b.Height, nil
+}
+
+// Block returns the block at the given height, heights without submissions
+// below the head are empty blocks
+func (s *Store) Block(height uint64) (*Block, error) {
+	head := s.Height()
+	if height == 0 || height > head {
+		return nil, fmt.Errorf("header: height %d is out of range, head is %d", height, head)
+	}
+
+	// nolint: gosec
+	data, err := os.ReadFile(s.blockPath(height))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Block{Height: height}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Block
+	err = json.Unmarshal(data, &b)
+	if err != nil {
+		return nil, fmt.Errorf("corrupted block %d: %w", height, err)
+	}
+
+	return &b, nil
+}
+
+func (s *Store) Get(height uint64, namespace, commitment []byte) (*Blob, error) {
+	b, err := s.Block(height)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blob := range b.Blobs {
+		if bytes.Equal(blob.Namespace, namespace) && bytes.Equal(blob.Commitment, commitment) {
+			return &blob, nil
+		}
+	}
+
+	return nil, ErrBlobNotFound
+}
+
+func (s *Store) GetAll(height uint64, namespaces [][]byte) ([]Blob, error) {
+	b, err := s.Block(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []Blob
+	for _, blob := range b.Blobs {
+		for _, ns := range namespaces {
+			if bytes.Equal(blob.Namespace, ns) {
+				blobs = append(blobs, blob)
+				break
+			}
+		}
+	}
+
+	if len(blobs) == 0 {
+		return nil, ErrBlobNotFound
+	}
+
+	return blobs, nil
+}
+
+func (s *Store) blockPath(height uint64) string {
+	return filepath.Join(s.root, fmt.Sprintf("%d.json", height))
+}
+
+// Namespace returns the celestia version 0 namespace of a namespace id
+func Namespace(id string) ([]byte, error) {
+	nID, err := hex.DecodeString(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace id %s: %w", id, err)
+	}
+	if len(nID) > namespaceSize-1 {
+		return nil, fmt.Errorf("namespace id %s is too long", id)
+	}
+
+	ns := make([]byte, namespaceSize)
+	ns[0] = namespaceVersion
+	copy(ns[namespaceSize-len(nID):], nID)
+
+	return ns, nil
+}
+
+// commitment identifies a blob within a block, unlike celestia it's not a
+// merkle root of the blob shares
+func commitment(namespace, data []byte) []byte {
+	h := sha256.New()
+	h.Write(namespace)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func randHex(size int) (string, error) {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
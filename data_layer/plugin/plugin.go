@@ -0,0 +1,380 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	callTimeout = 2 * time.Minute
+	infoTimeout = 10 * time.Second
+)
+
+// Plugin is a DataLayer implemented by an external executable
+type Plugin struct {
+	Name            string
+	Path            string
+	Home            string
+	Root            string
+	RPCEndpoint     string
+	MetricsEndpoint string
+
+	info *Info
+}
+
+// NewPlugin returns the DA plugin installed in the roller home under the given
+// name, its state is kept in the DA light node directory
+func NewPlugin(home, name string) *Plugin {
+	return &Plugin{
+		Name: name,
+		Path: roller.DAPluginPath(home, name),
+		Home: home,
+		Root: filepath.Join(home, consts.ConfigDirName.DALightNode),
+	}
+}
+
+// List returns the DA plugins installed in the roller home ordered by name
+func List(home string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(filepath.Join(home, consts.ConfigDirName.DAPlugins))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var plugins []*Plugin
+	for _, e := range entries {
+		if !roller.IsDAPluginInstalled(home, e.Name()) {
+			continue
+		}
+		plugins = append(plugins, NewPlugin(home, e.Name()))
+	}
+
+	sort.Slice(
+		plugins, func(i, j int) bool {
+			return plugins[i].Name < plugins[j].Name
+		},
+	)
+
+	return plugins, nil
+}
+
+func (p *Plugin) call(method string, nodeType string, timeout time.Duration, out any) error {
+	// the protocol version is checked before the first call, a plugin speaking
+	// a different version may misread the request
+	if method != MethodInfo {
+		_, err := p.Info()
+		if err != nil {
+			return err
+		}
+	}
+
+	req := Request{
+		ProtocolVersion: ProtocolVersion,
+		Method:          method,
+		Home:            p.Home,
+		Root:            p.Root,
+		NodeType:        nodeType,
+		RPCEndpoint:     p.RPCEndpoint,
+		MetricsEndpoint: p.MetricsEndpoint,
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	// nolint: gosec
+	c := exec.CommandContext(ctx, p.Path)
+	c.Stdin = bytes.NewReader(b)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	err = c.Run()
+	if ctx.Err() != nil {
+		return fmt.Errorf("da plugin %s: %s timed out after %s", p.Name, method, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf(
+			"da plugin %s: %s failed: %w: %s",
+			p.Name,
+			method,
+			err,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	var resp Response
+	err = json.Unmarshal(stdout.Bytes(), &resp)
+	if err != nil {
+		return fmt.Errorf("da plugin %s: invalid %s response: %w", p.Name, method, err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("da plugin %s: %s failed: %s", p.Name, method, resp.Error)
+	}
+
+	if out == nil || len(resp.Result) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(resp.Result, out)
+	if err != nil {
+		return fmt.Errorf("da plugin %s: invalid %s result: %w", p.Name, method, err)
+	}
+
+	return nil
+}
+
+// Info returns the plugin description, the plugin is rejected when it speaks
+// a different protocol version
+func (p *Plugin) Info() (*Info, error) {
+	if p.info != nil {
+		return p.info, nil
+	}
+
+	var info Info
+	err := p.call(MethodInfo, "", infoTimeout, &info)
+	if err != nil {
+		return nil, err
+	}
+	if info.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf(
+			"da plugin %s speaks protocol version %d, roller supports version %d",
+			p.Name,
+			info.ProtocolVersion,
+			ProtocolVersion,
+		)
+	}
+
+	p.info = &info
+	return p.info, nil
+}
+
+func (p *Plugin) GetDAAccountAddress() (*keys.KeyInfo, error) {
+	var addr AccountAddress
+	err := p.call(MethodAccountAddress, "", callTimeout, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if addr.Name == "" {
+		addr.Name = p.GetKeyName()
+	}
+
+	return &keys.KeyInfo{
+		Name:    addr.Name,
+		Address: addr.Address,
+	}, nil
+}
+
+func (p *Plugin) InitializeLightNodeConfig() (string, error) {
+	err := os.MkdirAll(p.Root, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	var res InitResult
+	err = p.call(MethodInit, "", callTimeout, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Mnemonic, nil
+}
+
+func (p *Plugin) CheckDABalance() ([]keys.NotFundedAddressData, error) {
+	var res []NotFundedAddress
+	err := p.call(MethodCheckBalance, "", callTimeout, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	var notFunded []keys.NotFundedAddressData
+	for _, a := range res {
+		notFunded = append(
+			notFunded, keys.NotFundedAddressData{
+				KeyName:         a.KeyName,
+				Address:         a.Address,
+				CurrentBalance:  a.CurrentBalance,
+				RequiredBalance: a.RequiredBalance,
+				Denom:           a.Denom,
+				Network:         a.Network,
+			},
+		)
+	}
+
+	return notFunded, nil
+}
+
+func (p *Plugin) GetStartDACmd() *exec.Cmd {
+	var res StartCommand
+	err := p.call(MethodStartCommand, "", callTimeout, &res)
+	if err != nil || res.Path == "" {
+		return nil
+	}
+
+	// nolint: gosec
+	c := exec.Command(res.Path, res.Args...)
+	if len(res.Env) > 0 {
+		c.Env = append(os.Environ(), res.Env...)
+	}
+
+	return c
+}
+
+func (p *Plugin) GetDAAccData(c roller.RollappConfig) ([]keys.AccountData, error) {
+	var res []AccountBalance
+	err := p.call(MethodAccountData, c.NodeType, callTimeout, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []keys.AccountData
+	for _, a := range res {
+		data = append(
+			data, keys.AccountData{
+				Address: a.Address,
+				Balance: keys.Balance{
+					Denom:  a.Denom,
+					Amount: a.Amount,
+				},
+			},
+		)
+	}
+
+	return data, nil
+}
+
+func (p *Plugin) GetLightNodeEndpoint() string {
+	var res Endpoint
+	err := p.call(MethodLightNodeEndpoint, "", callTimeout, &res)
+	if err != nil {
+		return ""
+	}
+
+	return res.Endpoint
+}
+
+// GetSequencerDAConfig returns the dymint da_config of the plugin, use
+// SequencerDASettings to retrieve it along with the matching da_layer
+func (p *Plugin) GetSequencerDAConfig(nt string) (string, error) {
+	cfg, err := p.sequencerDAConfig(nt)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.Config, nil
+}
+
+// GetSequencerDALayer returns the dymint da_layer the plugin submits with,
+// it defaults to the plugin name
+func (p *Plugin) GetSequencerDALayer(nt string) (string, error) {
+	cfg, err := p.sequencerDAConfig(nt)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.DALayer, nil
+}
+
+// SequencerDASettings returns the dymint da_layer and da_config of the
+// plugin from a single plugin call
+func (p *Plugin) SequencerDASettings(nt string) (string, string, error) {
+	cfg, err := p.sequencerDAConfig(nt)
+	if err != nil {
+		return "", "", err
+	}
+
+	return cfg.DALayer, cfg.Config, nil
+}
+
+func (p *Plugin) sequencerDAConfig(nt string) (*SequencerDAConfig, error) {
+	var res SequencerDAConfig
+	err := p.call(MethodSequencerDAConfig, nt, callTimeout, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.DALayer == "" {
+		res.DALayer = p.Name
+	}
+
+	return &res, nil
+}
+
+func (p *Plugin) SetRPCEndpoint(rpc string) {
+	p.RPCEndpoint = rpc
+}
+
+func (p *Plugin) SetMetricsEndpoint(endpoint string) {
+	p.MetricsEndpoint = endpoint
+}
+
+func (p *Plugin) GetNetworkName() string {
+	info, err := p.Info()
+	if err != nil {
+		return ""
+	}
+
+	return info.Network
+}
+
+func (p *Plugin) GetStatus(c roller.RollappConfig) string {
+	var res Status
+	err := p.call(MethodStatus, c.NodeType, callTimeout, &res)
+	if err != nil {
+		return "Unknown: " + err.Error()
+	}
+
+	return res.Status
+}
+
+func (p *Plugin) GetKeyName() string {
+	info, err := p.Info()
+	if err != nil || info.KeyName == "" {
+		return p.Name
+	}
+
+	return info.KeyName
+}
+
+func (p *Plugin) GetPrivateKey() (string, error) {
+	var res PrivateKey
+	err := p.call(MethodPrivateKey, "", callTimeout, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.PrivateKey, nil
+}
+
+func (p *Plugin) GetRootDirectory() string {
+	return p.Root
+}
+
+func (p *Plugin) GetNamespaceID() string {
+	var res Namespace
+	err := p.call(MethodNamespace, "", callTimeout, &res)
+	if err != nil {
+		return ""
+	}
+
+	return res.NamespaceID
+}
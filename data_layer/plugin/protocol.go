@@ -0,0 +1,121 @@
+package plugin
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// A DA plugin is an executable installed in <roller_home>/plugins/da/<name>,
+// the file name is the DA type used in roller.toml. Roller runs the plugin
+// once per operation, writes a single Request as JSON to its stdin and reads a
+// single Response as JSON from its stdout. Anything the plugin writes to
+// stderr is returned to the user when the call fails.
+//
+// The operations mirror the DataLayer interface:
+//
+//	info                 -> Info
+//	account_address      -> AccountAddress
+//	init                 -> InitResult
+//	check_balance        -> []NotFundedAddress
+//	start_command        -> StartCommand
+//	account_data         -> []AccountBalance
+//	light_node_endpoint  -> Endpoint
+//	sequencer_da_config  -> SequencerDAConfig
+//	status               -> Status
+//	private_key          -> PrivateKey
+//	namespace            -> Namespace
+const ProtocolVersion = 1
+
+const (
+	MethodInfo              = "info"
+	MethodAccountAddress    = "account_address"
+	MethodInit              = "init"
+	MethodCheckBalance      = "check_balance"
+	MethodStartCommand      = "start_command"
+	MethodAccountData       = "account_data"
+	MethodLightNodeEndpoint = "light_node_endpoint"
+	MethodSequencerDAConfig = "sequencer_da_config"
+	MethodStatus            = "status"
+	MethodPrivateKey        = "private_key"
+	MethodNamespace         = "namespace"
+)
+
+type Request struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	Method          string `json:"method"`
+	// Home is the roller home, plugins keep their state in Root
+	Home            string `json:"home"`
+	Root            string `json:"root"`
+	NodeType        string `json:"node_type,omitempty"`
+	RPCEndpoint     string `json:"rpc_endpoint,omitempty"`
+	MetricsEndpoint string `json:"metrics_endpoint,omitempty"`
+}
+
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type Info struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ProtocolVersion int    `json:"protocol_version"`
+	Network         string `json:"network"`
+	KeyName         string `json:"key_name"`
+	Denom           string `json:"denom"`
+}
+
+type AccountAddress struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type InitResult struct {
+	Mnemonic string `json:"mnemonic"`
+}
+
+type NotFundedAddress struct {
+	KeyName         string   `json:"key_name"`
+	Address         string   `json:"address"`
+	CurrentBalance  *big.Int `json:"current_balance"`
+	RequiredBalance *big.Int `json:"required_balance"`
+	Denom           string   `json:"denom"`
+	Network         string   `json:"network"`
+}
+
+// StartCommand is the long running process of the DA, an empty Path means
+// the DA doesn't require a local node
+type StartCommand struct {
+	Path string   `json:"path"`
+	Args []string `json:"args"`
+	Env  []string `json:"env"`
+}
+
+type AccountBalance struct {
+	Address string   `json:"address"`
+	Denom   string   `json:"denom"`
+	Amount  *big.Int `json:"amount"`
+}
+
+type Endpoint struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// SequencerDAConfig is written to dymint.toml, DALayer is the dymint da_layer
+// value and Config the da_config value
+type SequencerDAConfig struct {
+	DALayer string `json:"da_layer"`
+	Config  string `json:"config"`
+}
+
+type Status struct {
+	Status string `json:"status"`
+}
+
+type PrivateKey struct {
+	PrivateKey string `json:"private_key"`
+}
+
+type Namespace struct {
+	NamespaceID string `json:"namespace_id"`
+}
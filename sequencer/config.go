@@ -11,6 +11,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
 	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/data_layer/plugin"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/sequencer"
@@ -70,7 +71,10 @@ func UpdateDymintDAConfig(rlpCfg roller.RollappConfig) error {
 }
 
 func updateDaConfigInToml(rlpCfg roller.RollappConfig, dymintCfg *toml.Tree) error {
-	damanager := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home)
+	damanager, err := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home)
+	if err != nil {
+		return err
+	}
 	dymintCfg.Set("da_layer", "mock")
 	// daConfig := damanager.GetSequencerDAConfig()
 	// dymintCfg.Set("da_config", daConfig)
@@ -82,11 +86,36 @@ func updateDaConfigInToml(rlpCfg roller.RollappConfig, dymintCfg *toml.Tree) err
 				damanager.DataLayer,
 			)
 		}
-		dymintCfg.Set("namespace_id", celDAManager.NamespaceID)
+		dymintCfg.Set("namespace_id", celDAManager.GetNamespaceID())
 	}
 
+	// the local DA speaks the celestia node API
 	if rlpCfg.DA.Backend == consts.Local {
-		dymintCfg.Set("da_layer", "mock")
+		dymintCfg.Set("da_layer", string(consts.Celestia))
+		daConfig, err := damanager.GetSequencerDAConfig(rlpCfg.NodeType)
+		if err != nil {
+			return err
+		}
+		dymintCfg.Set("namespace_id", damanager.GetNamespaceID())
+		dymintCfg.Set("da_config", daConfig)
+	}
+
+	if rlpCfg.DA.Backend == consts.Avail {
+		daConfig, err := damanager.GetSequencerDAConfig(rlpCfg.NodeType)
+		if err != nil {
+			return err
+		}
+		dymintCfg.Set("da_layer", string(consts.Avail))
+		dymintCfg.Set("da_config", daConfig)
+	}
+
+	if p, ok := damanager.DataLayer.(*plugin.Plugin); ok {
+		daLayer, daConfig, err := p.SequencerDASettings(rlpCfg.NodeType)
+		if err != nil {
+			return err
+		}
+		dymintCfg.Set("da_layer", daLayer)
+		dymintCfg.Set("da_config", daConfig)
 	}
 
 	return nil
@@ -0,0 +1,209 @@
+package daauth
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/restart"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+// The light node signs its auth tokens with a secret stored in its keystore,
+// the tokens don't expire so the only way to invalidate them is to replace
+// the secret. A new secret is generated when a token is requested without one
+const jwtSecretKeyName = "jwt-secret.jwt"
+
+const (
+	redacted = "<redacted>"
+
+	revokeCheckTimeout  = 2 * time.Minute
+	revokeCheckInterval = 5 * time.Second
+)
+
+// the keystore of celestia-node names the key files after the unpadded
+// base32 encoding of the key name
+func jwtSecretPath(home string) string {
+	return filepath.Join(
+		home,
+		consts.ConfigDirName.DALightNode,
+		consts.KeysDirName,
+		base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(jwtSecretKeyName)),
+	)
+}
+
+// PermissionForNodeType returns the token permission the node type needs,
+// sequencers submit batches while full nodes only read them
+func PermissionForNodeType(nt string) (string, error) {
+	switch nt {
+	case consts.NodeType.Sequencer:
+		return consts.DaAuthTokenType.Admin, nil
+	case consts.NodeType.FullNode:
+		return consts.DaAuthTokenType.Read, nil
+	default:
+		return "", fmt.Errorf("unsupported node type: %s", nt)
+	}
+}
+
+// Redact hides all but the edges of a token so it can be told apart from
+// other tokens without being usable
+func Redact(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 16 {
+		return redacted
+	}
+
+	return token[:4] + "..." + token[len(token)-4:]
+}
+
+// Permissions returns the permissions a light node token grants, the token
+// signature isn't verified
+func Permissions(token string) ([]string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+
+	var claims struct {
+		Allow []string `json:"Allow"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+
+	return claims.Allow, nil
+}
+
+// GetDAConfigToken returns the auth token dymint uses to reach the DA node
+func GetDAConfigToken(home string) (string, error) {
+	daCfg, err := sequencer.GetDAConfig(home)
+	if err != nil {
+		return "", err
+	}
+
+	token, _ := daCfg["auth_token"].(string)
+	return token, nil
+}
+
+// SetDAConfigToken replaces the auth token of the dymint da_config
+func SetDAConfigToken(home, token string) error {
+	return sequencer.SetDAConfigField(home, "auth_token", token)
+}
+
+// Rotate invalidates every token issued by the light node and writes a new
+// one with the given permission to the dymint da_config. The light node has
+// to be restarted to pick up the new secret
+func Rotate(rollerData roller.RollappConfig, permission string) (string, error) {
+	if rollerData.DA.Backend != consts.Celestia {
+		return "", fmt.Errorf("auth tokens are only managed for %s", consts.Celestia)
+	}
+
+	err := revokeAll(rollerData.Home)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := celestia.NewCelestia(rollerData.Home).GetAuthToken(permission)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue a new %s token: %w", permission, err)
+	}
+
+	err = SetDAConfigToken(rollerData.Home, token)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// Revoke invalidates every token issued by the light node, including the one
+// of the dymint da_config which is removed. The rollapp can't reach the DA
+// until a new token is issued with Rotate
+func Revoke(rollerData roller.RollappConfig) error {
+	if rollerData.DA.Backend != consts.Celestia {
+		return fmt.Errorf("auth tokens are only managed for %s", consts.Celestia)
+	}
+
+	err := revokeAll(rollerData.Home)
+	if err != nil {
+		return err
+	}
+
+	return SetDAConfigToken(rollerData.Home, "")
+}
+
+func revokeAll(home string) error {
+	err := os.Remove(jwtSecretPath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf(
+				"the token signing secret %s doesn't exist, no token was revoked",
+				jwtSecretPath(home),
+			)
+		}
+		return fmt.Errorf("failed to remove the token signing secret: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyRevoked waits for the restarted light node to come up and checks that
+// it rejects a token issued before the secret was replaced
+func VerifyRevoked(home, token string) error {
+	c := celestia.NewCelestia(home)
+	client := &celestia.NodeClient{Endpoint: c.GetLightNodeEndpoint(), AuthToken: token}
+
+	deadline := time.Now().Add(revokeCheckTimeout)
+	for {
+		_, err := client.LocalHead()
+		switch {
+		case errors.Is(err, celestia.ErrUnauthorized):
+			return nil
+		case err == nil:
+			return errors.New("the light node still accepts the old token")
+		case time.Now().After(deadline):
+			return fmt.Errorf("the light node didn't come up: %w", err)
+		}
+
+		time.Sleep(revokeCheckInterval)
+	}
+}
+
+// RestartServices restarts the running light client and rollapp services so
+// they pick up the new secret and token, the light client goes first as the
+// rollapp connects to it on startup. The restarted services are returned
+func RestartServices(home string) ([]string, error) {
+	var active []string
+	for _, s := range []string{"da-light-client", "rollapp"} {
+		ok, err := servicemanager.IsServiceActive(s)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			active = append(active, s)
+		}
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	return active, restart.RestartSystemdServices(active, home)
+}
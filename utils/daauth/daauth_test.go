@@ -0,0 +1,87 @@
+package daauth
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+func TestJWTSecretPath(t *testing.T) {
+	// celestia-node stores the key as base32("jwt-secret.jwt") without padding
+	require.Equal(
+		t,
+		filepath.Join("/home", consts.ConfigDirName.DALightNode, consts.KeysDirName, "NJ3XILLTMVRXEZLUFZVHO5A"),
+		jwtSecretPath("/home"),
+	)
+}
+
+func TestRevokeAll(t *testing.T) {
+	home := t.TempDir()
+
+	err := revokeAll(home)
+	require.ErrorContains(t, err, "doesn't exist")
+
+	p := jwtSecretPath(home)
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte("secret"), 0o600))
+
+	require.NoError(t, revokeAll(home))
+	require.NoFileExists(t, p)
+}
+
+func TestVerifyRevoked(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{name: "rejected", status: http.StatusUnauthorized},
+		{name: "accepted", status: http.StatusOK, wantErr: "still accepts the old token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				srv := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							require.Equal(t, "Bearer old-token", r.Header.Get("Authorization"))
+							w.WriteHeader(tt.status)
+							_, _ = w.Write([]byte(`{"result":{"header":{"height":"10"}}}`))
+						},
+					),
+				)
+				defer srv.Close()
+
+				home := t.TempDir()
+				_, port, err := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+				require.NoError(t, err)
+				lcDir := filepath.Join(home, consts.ConfigDirName.DALightNode)
+				require.NoError(t, os.MkdirAll(lcDir, 0o755))
+				require.NoError(
+					t,
+					os.WriteFile(
+						filepath.Join(lcDir, "config.toml"),
+						[]byte("[RPC]\nPort = \""+port+"\"\n"),
+						0o600,
+					),
+				)
+
+				err = VerifyRevoked(home, "old-token")
+				if tt.wantErr == "" {
+					require.NoError(t, err)
+				} else {
+					require.ErrorContains(t, err, tt.wantErr)
+				}
+			},
+		)
+	}
+}
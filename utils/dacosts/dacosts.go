@@ -0,0 +1,329 @@
+package dacosts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// The DA account balance is sampled whenever the sequencer posts a new batch,
+// the spend is derived from the balance deltas between the samples. Samples are
+// appended to a JSON lines file in the roller home, outside of the DA directory
+// so the history survives switching the DA
+const samplesFileName = "da-costs.jsonl"
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+
+	// retention is how long samples are kept, the report covers the last week
+	retention = week
+)
+
+// Sample is the DA account balance at a point in time together with the
+// latest rollapp height posted to the hub
+type Sample struct {
+	Time          time.Time     `json:"time"`
+	DA            consts.DAType `json:"da"`
+	Address       string        `json:"address"`
+	Denom         string        `json:"denom"`
+	Balance       *big.Int      `json:"balance"`
+	RollappHeight uint64        `json:"rollapp_height"`
+}
+
+type DaySpend struct {
+	Date   string   `json:"date"`
+	Spend  *big.Int `json:"spend"`
+	Blocks uint64   `json:"blocks"`
+}
+
+type Report struct {
+	DA             consts.DAType `json:"da"`
+	Address        string        `json:"address"`
+	Denom          string        `json:"denom"`
+	Balance        *big.Int      `json:"balance"`
+	DailySpend     *big.Int      `json:"daily_spend"`
+	WeeklySpend    *big.Int      `json:"weekly_spend"`
+	WeeklyBlocks   uint64        `json:"weekly_blocks"`
+	CostPerBlock   *float64      `json:"cost_per_block,omitempty"`
+	SpendPerDay    *float64      `json:"spend_per_day,omitempty"`
+	RunwayDays     *float64      `json:"runway_days,omitempty"`
+	Deposits       *big.Int      `json:"deposits"`
+	FirstSample    time.Time     `json:"first_sample"`
+	LastSample     time.Time     `json:"last_sample"`
+	NumSamples     int           `json:"num_samples"`
+	Days           []DaySpend    `json:"days"`
+	GeneratedAt    time.Time     `json:"generated_at"`
+	SamplingWindow string        `json:"sampling_window"`
+}
+
+func SamplesPath(home string) string {
+	return filepath.Join(home, samplesFileName)
+}
+
+// LoadSamples returns the recorded samples of the roller home in the order
+// they were taken
+func LoadSamples(home string) ([]Sample, error) {
+	f, err := os.Open(SamplesPath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	var samples []Sample
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+
+		var sample Sample
+		err := json.Unmarshal(s.Bytes(), &sample)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample in %s: %w", SamplesPath(home), err)
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, s.Err()
+}
+
+func appendSample(home string, sample Sample) error {
+	b, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+
+	// nolint: gosec
+	f, err := os.OpenFile(SamplesPath(home), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// writeSamples replaces the samples file, the file is swapped in place so a
+// concurrent reader never sees a partial file
+func writeSamples(home string, samples []Sample) error {
+	var buf []byte
+	for _, sample := range samples {
+		b, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, b...), '\n')
+	}
+
+	tmp := SamplesPath(home) + ".tmp"
+	err := os.WriteFile(tmp, buf, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, SamplesPath(home))
+}
+
+// prune drops the samples older than the retention. The newest of them is
+// kept as the baseline of the first balance delta inside the window
+func prune(samples []Sample, now time.Time) []Sample {
+	cutoff := now.Add(-retention)
+
+	i := 0
+	for i+1 < len(samples) && samples[i+1].Time.Before(cutoff) {
+		i++
+	}
+
+	return samples[i:]
+}
+
+// Record samples the DA account balance and the latest rollapp height posted
+// to the hub. The sample is only stored when either changed since the previous
+// one, which is at most once per batch. Samples older than the report window
+// are pruned. The returned sample is nil when nothing was recorded
+func Record(cfg roller.RollappConfig) (*Sample, error) {
+	if cfg.DA.Backend == consts.Local {
+		return nil, nil
+	}
+
+	damanager, err := datalayer.NewDAManager(cfg.DA.Backend, cfg.Home)
+	if err != nil {
+		return nil, err
+	}
+	accData, err := damanager.GetDAAccData(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the DA balance: %w", err)
+	}
+	if len(accData) == 0 || accData[0].Balance.Amount == nil {
+		return nil, errors.New("the DA doesn't report an account balance")
+	}
+
+	sample := Sample{
+		Time:    time.Now().UTC(),
+		DA:      cfg.DA.Backend,
+		Address: accData[0].Address,
+		Denom:   accData[0].Balance.Denom,
+		Balance: accData[0].Balance.Amount,
+	}
+
+	if cfg.HubData.ID != consts.MockHubID {
+		si, err := rollapp.GetStateInfo(cfg.RollappID, "", cfg.HubData)
+		if err == nil {
+			sample.RollappHeight = lastHeight(si)
+		}
+	}
+
+	samples, err := LoadSamples(cfg.Home)
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) > 0 {
+		last := samples[len(samples)-1]
+		if last.Address == sample.Address &&
+			last.Balance.Cmp(sample.Balance) == 0 &&
+			last.RollappHeight == sample.RollappHeight {
+			return nil, nil
+		}
+	}
+
+	samples = append(samples, sample)
+	kept := prune(samples, sample.Time)
+	if len(kept) < len(samples) {
+		err = writeSamples(cfg.Home, kept)
+	} else {
+		err = appendSample(cfg.Home, sample)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &sample, nil
+}
+
+func lastHeight(si *rollapp.StateInfo) uint64 {
+	start, err := strconv.ParseUint(si.StartHeight, 10, 64)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.ParseUint(si.NumBlocks, 10, 64)
+	if err != nil || n == 0 {
+		return 0
+	}
+
+	return start + n - 1
+}
+
+// BuildReport derives the spend from the balance deltas of consecutive
+// samples of the current DA account. A balance increase is a deposit and
+// doesn't count as spend. The rates are computed over the last week, or the
+// sampled period when it's shorter
+func BuildReport(samples []Sample, now time.Time) (*Report, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("no DA balance samples were recorded yet")
+	}
+
+	// only the samples of the current account are comparable
+	last := samples[len(samples)-1]
+	var account []Sample
+	for _, s := range samples {
+		if s.DA == last.DA && s.Address == last.Address {
+			account = append(account, s)
+		}
+	}
+
+	r := &Report{
+		DA:          last.DA,
+		Address:     last.Address,
+		Denom:       last.Denom,
+		Balance:     last.Balance,
+		DailySpend:  big.NewInt(0),
+		WeeklySpend: big.NewInt(0),
+		Deposits:    big.NewInt(0),
+		FirstSample: account[0].Time,
+		LastSample:  last.Time,
+		NumSamples:  len(account),
+		GeneratedAt: now.UTC(),
+	}
+
+	days := map[string]*DaySpend{}
+	for i := 6; i >= 0; i-- {
+		d := now.UTC().Add(-time.Duration(i) * day).Format(time.DateOnly)
+		ds := &DaySpend{Date: d, Spend: big.NewInt(0)}
+		days[d] = ds
+		r.Days = append(r.Days, DaySpend{Date: d})
+	}
+
+	windowStart := now.Add(-week)
+	if account[0].Time.After(windowStart) {
+		windowStart = account[0].Time
+	}
+
+	for i := 1; i < len(account); i++ {
+		prev, cur := account[i-1], account[i]
+
+		delta := new(big.Int).Sub(prev.Balance, cur.Balance)
+		var blocks uint64
+		if cur.RollappHeight > prev.RollappHeight && prev.RollappHeight > 0 {
+			blocks = cur.RollappHeight - prev.RollappHeight
+		}
+
+		if delta.Sign() < 0 {
+			r.Deposits.Add(r.Deposits, new(big.Int).Neg(delta))
+			delta = big.NewInt(0)
+		}
+
+		age := now.Sub(cur.Time)
+		if age <= day {
+			r.DailySpend.Add(r.DailySpend, delta)
+		}
+		if age <= week {
+			r.WeeklySpend.Add(r.WeeklySpend, delta)
+			r.WeeklyBlocks += blocks
+		}
+		if ds, ok := days[cur.Time.UTC().Format(time.DateOnly)]; ok {
+			ds.Spend.Add(ds.Spend, delta)
+			ds.Blocks += blocks
+		}
+	}
+
+	for i := range r.Days {
+		r.Days[i] = *days[r.Days[i].Date]
+	}
+
+	weekly, _ := new(big.Float).SetInt(r.WeeklySpend).Float64()
+	if r.WeeklyBlocks > 0 {
+		cpb := weekly / float64(r.WeeklyBlocks)
+		r.CostPerBlock = &cpb
+	}
+
+	window := now.Sub(windowStart)
+	r.SamplingWindow = window.Round(time.Minute).String()
+	if window > 0 && r.WeeklySpend.Sign() > 0 {
+		spd := weekly / (window.Hours() / 24)
+		r.SpendPerDay = &spd
+
+		balance, _ := new(big.Float).SetInt(r.Balance).Float64()
+		runway := balance / spd
+		r.RunwayDays = &runway
+	}
+
+	return r, nil
+}
@@ -0,0 +1,263 @@
+package dacosts
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+var testNow = time.Date(2024, 9, 10, 12, 0, 0, 0, time.UTC)
+
+func sample(age time.Duration, balance int64, height uint64) Sample {
+	return Sample{
+		Time:          testNow.Add(-age),
+		DA:            consts.Celestia,
+		Address:       "celestia1account",
+		Denom:         "utia",
+		Balance:       big.NewInt(balance),
+		RollappHeight: height,
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	previous := sample(4*day, 99999, 50)
+	previous.Address = "celestia1previous"
+
+	samples := []Sample{
+		previous,
+		sample(3*day, 1000, 100),
+		// 100 spent on 100 blocks
+		sample(2*day, 900, 200),
+		// a deposit of 500, the 50 blocks are free
+		sample(36*time.Hour, 1400, 250),
+		// 100 spent on 50 blocks within the last day
+		sample(12*time.Hour, 1300, 300),
+	}
+
+	r, err := BuildReport(samples, testNow)
+	require.NoError(t, err)
+
+	require.Equal(t, "celestia1account", r.Address)
+	require.Equal(t, 4, r.NumSamples)
+	require.Equal(t, samples[1].Time, r.FirstSample)
+	require.Equal(t, samples[4].Time, r.LastSample)
+	require.Equal(t, "1300", r.Balance.String())
+	require.Equal(t, "500", r.Deposits.String())
+	require.Equal(t, "100", r.DailySpend.String())
+	require.Equal(t, "200", r.WeeklySpend.String())
+	require.Equal(t, uint64(200), r.WeeklyBlocks)
+	require.Equal(t, "72h0m0s", r.SamplingWindow)
+
+	require.NotNil(t, r.CostPerBlock)
+	require.InDelta(t, 1.0, *r.CostPerBlock, 1e-9)
+	// 200 over the 3 sampled days
+	require.NotNil(t, r.SpendPerDay)
+	require.InDelta(t, 200.0/3, *r.SpendPerDay, 1e-9)
+	require.NotNil(t, r.RunwayDays)
+	require.InDelta(t, 1300/(200.0/3), *r.RunwayDays, 1e-9)
+
+	require.Len(t, r.Days, 7)
+	require.Equal(t, "2024-09-04", r.Days[0].Date)
+	days := map[string]DaySpend{}
+	for _, d := range r.Days {
+		days[d.Date] = d
+	}
+	require.Equal(t, "100", days["2024-09-08"].Spend.String())
+	require.Equal(t, uint64(100), days["2024-09-08"].Blocks)
+	require.Equal(t, "0", days["2024-09-09"].Spend.String())
+	require.Equal(t, uint64(50), days["2024-09-09"].Blocks)
+	require.Equal(t, "100", days["2024-09-10"].Spend.String())
+	require.Equal(t, uint64(50), days["2024-09-10"].Blocks)
+	require.Equal(t, "0", days["2024-09-04"].Spend.String())
+}
+
+func TestBuildReportWindow(t *testing.T) {
+	samples := []Sample{
+		sample(10*day, 5000, 0),
+		// spent before the window
+		sample(9*day, 4000, 0),
+		// 100 spent inside the window, the rollapp height is unknown
+		sample(2*day, 3900, 0),
+	}
+
+	r, err := BuildReport(samples, testNow)
+	require.NoError(t, err)
+
+	require.Equal(t, "100", r.WeeklySpend.String())
+	require.Equal(t, "0", r.DailySpend.String())
+	require.Equal(t, uint64(0), r.WeeklyBlocks)
+	require.Nil(t, r.CostPerBlock)
+	require.Equal(t, "168h0m0s", r.SamplingWindow)
+	require.InDelta(t, 100.0/7, *r.SpendPerDay, 1e-9)
+	require.InDelta(t, 3900/(100.0/7), *r.RunwayDays, 1e-9)
+}
+
+func TestBuildReportWithoutSpend(t *testing.T) {
+	_, err := BuildReport(nil, testNow)
+	require.Error(t, err)
+
+	r, err := BuildReport([]Sample{sample(day, 1000, 10)}, testNow)
+	require.NoError(t, err)
+	require.Equal(t, "0", r.WeeklySpend.String())
+	require.Nil(t, r.SpendPerDay)
+	require.Nil(t, r.RunwayDays)
+
+	// deposits only
+	r, err = BuildReport([]Sample{sample(2*day, 1000, 10), sample(day, 2000, 20)}, testNow)
+	require.NoError(t, err)
+	require.Equal(t, "1000", r.Deposits.String())
+	require.Equal(t, "0", r.WeeklySpend.String())
+	require.Nil(t, r.RunwayDays)
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name string
+		ages []time.Duration
+		want []time.Duration
+	}{
+		{
+			name: "nothing to prune",
+			ages: []time.Duration{6 * day, day},
+			want: []time.Duration{6 * day, day},
+		},
+		{
+			name: "the newest old sample is kept as the baseline",
+			ages: []time.Duration{10 * day, 9 * day, 8 * day, day},
+			want: []time.Duration{8 * day, day},
+		},
+		{
+			name: "only old samples",
+			ages: []time.Duration{10 * day, 9 * day},
+			want: []time.Duration{9 * day},
+		},
+		{
+			name: "no samples",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				var samples []Sample
+				for _, a := range tc.ages {
+					samples = append(samples, sample(a, 1, 1))
+				}
+
+				var got []time.Duration
+				for _, s := range prune(samples, testNow) {
+					got = append(got, testNow.Sub(s.Time))
+				}
+				require.Equal(t, tc.want, got)
+			},
+		)
+	}
+}
+
+const testPlugin = "testda"
+
+// setupPluginHome creates a roller home whose DA is a plugin reporting the
+// balance stored in the balance file of the home
+func setupPluginHome(t *testing.T) (string, func(balance string)) {
+	t.Helper()
+
+	home := t.TempDir()
+	require.NoError(
+		t, roller.WriteConfig(
+			roller.RollappConfig{
+				Home:    home,
+				HubData: consts.HubData{ID: consts.MockHubID},
+				DA:      consts.DaData{Backend: testPlugin, ID: "testnet"},
+			},
+		),
+	)
+
+	balancePath := filepath.Join(home, "balance")
+	script := `#!/bin/sh
+req=$(cat)
+case "$req" in
+*'"method":"info"'*)
+  echo '{"result":{"name":"testda","protocol_version":1}}' ;;
+*'"method":"account_data"'*)
+  echo "{\"result\":[{\"address\":\"test1account\",\"denom\":\"utest\",\"amount\":$(cat ` +
+		balancePath + `)}]}" ;;
+*)
+  echo '{"error":"unsupported"}' ;;
+esac
+`
+	pluginPath := roller.DAPluginPath(home, testPlugin)
+	require.NoError(t, os.MkdirAll(filepath.Dir(pluginPath), 0o755))
+	// nolint: gosec
+	require.NoError(t, os.WriteFile(pluginPath, []byte(script), 0o755))
+
+	setBalance := func(balance string) {
+		require.NoError(t, os.WriteFile(balancePath, []byte(balance), 0o644))
+	}
+	setBalance("1000")
+
+	return home, setBalance
+}
+
+func TestRecord(t *testing.T) {
+	home, setBalance := setupPluginHome(t)
+	cfg, err := roller.LoadConfig(home)
+	require.NoError(t, err)
+
+	s, err := Record(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	require.Equal(t, "test1account", s.Address)
+	require.Equal(t, "utest", s.Denom)
+	require.Equal(t, "1000", s.Balance.String())
+
+	// nothing changed since the previous sample
+	s, err = Record(cfg)
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	setBalance("900")
+	s, err = Record(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	samples, err := LoadSamples(home)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	require.Equal(t, "1000", samples[0].Balance.String())
+	require.Equal(t, "900", samples[1].Balance.String())
+}
+
+func TestRecordPrunesOldSamples(t *testing.T) {
+	home, _ := setupPluginHome(t)
+	cfg, err := roller.LoadConfig(home)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	old := []Sample{
+		{Time: now.Add(-10 * day), Address: "test1account", Balance: big.NewInt(3000)},
+		{Time: now.Add(-9 * day), Address: "test1account", Balance: big.NewInt(2000)},
+		{Time: now.Add(-day), Address: "test1account", Balance: big.NewInt(1500)},
+	}
+	require.NoError(t, writeSamples(home, old))
+
+	s, err := Record(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	samples, err := LoadSamples(home)
+	require.NoError(t, err)
+	require.Len(t, samples, 3)
+	require.Equal(t, "2000", samples[0].Balance.String())
+	require.Equal(t, "1500", samples[1].Balance.String())
+	require.Equal(t, "1000", samples[2].Balance.String())
+
+	_, err = os.Stat(SamplesPath(home) + ".tmp")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
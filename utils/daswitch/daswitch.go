@@ -0,0 +1,506 @@
+package daswitch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/data_layer/celestia/lightclient"
+	"github.com/dymensionxyz/roller/data_layer/plugin"
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/genesis"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// A switch moves the light node directory aside and records the previous DA
+// configuration, both are kept in the da-switch directory of the roller home
+// until the switch is rolled back
+const rollbackFileName = "rollback.json"
+
+// dymintDAFields are the dymint.toml fields that depend on the DA
+var dymintDAFields = []string{"da_layer", "da_config", "namespace_id"}
+
+// Rollback is the DA configuration before a switch
+type Rollback struct {
+	Time            time.Time         `json:"time"`
+	DA              consts.DaData     `json:"da"`
+	Dymint          map[string]string `json:"dymint"`
+	LightNodeBackup string            `json:"light_node_backup"`
+}
+
+// Compatibility compares the DA a switch targets with the DA the rollapp and
+// the hub know about
+type Compatibility struct {
+	// DALayer is the dymint da_layer of the target DA
+	DALayer string
+	// RollappDA is the DA of the rollapp params, dymint refuses to start when
+	// it differs from its da_layer
+	RollappDA       string
+	RollappDASource string
+	// HubDAClient is the DA of the latest state update on the hub, empty
+	// when no state update was posted yet
+	HubDAClient string
+}
+
+func Dir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.DASwitch)
+}
+
+func rollbackPath(home string) string {
+	return filepath.Join(Dir(home), rollbackFileName)
+}
+
+func lightNodeDir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.DALightNode)
+}
+
+// LoadRollback returns the configuration recorded by the last switch, nil
+// when there is nothing to roll back
+func LoadRollback(home string) (*Rollback, error) {
+	b, err := os.ReadFile(rollbackPath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rb Rollback
+	err = json.Unmarshal(b, &rb)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", rollbackPath(home), err)
+	}
+
+	return &rb, nil
+}
+
+// ResolveNetwork returns the DA configuration of a known DA network or of an
+// installed DA plugin
+func ResolveNetwork(home, network string) (consts.DaData, error) {
+	if daData, ok := consts.DaNetworks[network]; ok {
+		return daData, nil
+	}
+
+	if roller.IsDAPluginInstalled(home, network) {
+		info, err := plugin.NewPlugin(home, network).Info()
+		if err != nil {
+			return consts.DaData{}, err
+		}
+
+		return consts.DaData{
+			Backend: consts.DAType(network),
+			ID:      consts.DaNetwork(info.Network),
+		}, nil
+	}
+
+	var networks []string
+	for n := range consts.DaNetworks {
+		networks = append(networks, n)
+	}
+
+	return consts.DaData{}, fmt.Errorf(
+		"unknown DA network %s, use one of %s or an installed DA plugin",
+		network,
+		strings.Join(networks, ", "),
+	)
+}
+
+// DALayer returns the dymint da_layer used for the DA backend
+func DALayer(home string, backend consts.DAType, nt string) (string, error) {
+	switch backend {
+	case consts.Celestia, consts.Local:
+		// the local DA speaks the celestia node API
+		return string(consts.Celestia), nil
+	case consts.Avail:
+		return string(consts.Avail), nil
+	default:
+		return plugin.NewPlugin(home, string(backend)).GetSequencerDALayer(nt)
+	}
+}
+
+// RollappDA returns the DA of the rollapp params, queried from the local
+// rollapp node when it runs and read from the genesis otherwise
+func RollappDA(home string) (string, string, error) {
+	cmd := exec.Command(
+		consts.Executables.RollappEVM,
+		"q", "rollappparams", "params",
+		"-o", "json", "--node", consts.DefaultRollappRPC,
+	)
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err == nil {
+		var resp struct {
+			Params struct {
+				Da string `json:"da"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(out.Bytes(), &resp) == nil && resp.Params.Da != "" {
+			return resp.Params.Da, "rollapp node", nil
+		}
+	}
+
+	as, err := genesis.GetGenesisAppState(home)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the rollapp params from the genesis: %w", err)
+	}
+
+	return as.RollappParams.Params.Da, "genesis", nil
+}
+
+// HubDAClient returns the DA of the latest state update posted to the hub
+func HubDAClient(rollerData roller.RollappConfig) (string, error) {
+	if rollerData.HubData.ID == consts.MockHubID {
+		return "", nil
+	}
+
+	si, err := rollapp.GetStateInfo(rollerData.RollappID, "", rollerData.HubData)
+	if err != nil {
+		if strings.Contains(err.Error(), "NotFound") {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return strings.SplitN(si.DAPath, "|", 2)[0], nil
+}
+
+// Check verifies that the rollapp can run on the target DA. Switching the
+// backend requires updating the DA of the rollapp params first
+func Check(rollerData roller.RollappConfig, target consts.DaData) (*Compatibility, error) {
+	daLayer, err := DALayer(rollerData.Home, target.Backend, rollerData.NodeType)
+	if err != nil {
+		return nil, err
+	}
+
+	raDA, source, err := RollappDA(rollerData.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	hubDA, err := HubDAClient(rollerData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the latest state update: %w", err)
+	}
+
+	c := &Compatibility{
+		DALayer:         daLayer,
+		RollappDA:       raDA,
+		RollappDASource: source,
+		HubDAClient:     hubDA,
+	}
+	if raDA != daLayer {
+		return c, fmt.Errorf(
+			"the rollapp params (%s) use %s as DA while %s requires %s, the rollapp params have to be updated first",
+			source,
+			raDA,
+			target.ID,
+			daLayer,
+		)
+	}
+
+	return c, nil
+}
+
+// MatchesHub reports whether the DA registered on the hub by the latest state
+// update is the DA layer of the target, rollapps without state updates match
+func (c *Compatibility) MatchesHub() bool {
+	return c.HubDAClient == "" || c.HubDAClient == c.DALayer
+}
+
+// WaitForStateUpdate waits for the next state update of the rollapp to be
+// posted to the hub and returns the last height it covers. Stopping the
+// sequencer right after a state update leaves no batch in flight, the blocks
+// produced since are submitted to the new DA
+func WaitForStateUpdate(
+	rollerData roller.RollappConfig,
+	interval, timeout time.Duration,
+) (uint64, error) {
+	current, err := rollapp.GetStateInfo(rollerData.RollappID, "", rollerData.HubData)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	for {
+		si, err := rollapp.GetStateInfo(rollerData.RollappID, "", rollerData.HubData)
+		if err == nil && si.StateInfoIndex.Index != current.StateInfoIndex.Index {
+			return lastHeight(si)
+		}
+
+		if timeout > 0 && time.Since(start) > timeout {
+			return 0, errors.New("timed out waiting for the next state update")
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+func lastHeight(si *rollapp.StateInfo) (uint64, error) {
+	start, err := strconv.ParseUint(si.StartHeight, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(si.NumBlocks, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return start + n - 1, nil
+}
+
+// Switch moves the rollapp to the target DA: the current light node is moved
+// aside, a light node is initialized for the target DA and dymint.toml is
+// migrated. The services have to be stopped. The returned key is the new DA
+// account, nil for DAs without one
+func Switch(rollerData roller.RollappConfig, target consts.DaData) (*keys.KeyInfo, error) {
+	damanager, err := datalayer.NewDAManager(target.Backend, rollerData.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	dymintPath := sequencerutils.GetDymintFilePath(rollerData.Home)
+	dymintCfg, err := toml.LoadFile(dymintPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rb := Rollback{
+		Time:   time.Now().UTC(),
+		DA:     rollerData.DA,
+		Dymint: map[string]string{},
+	}
+	for _, f := range dymintDAFields {
+		if v, ok := dymintCfg.Get(f).(string); ok {
+			rb.Dymint[f] = v
+		}
+	}
+
+	rb.LightNodeBackup, err = moveLightNodeAside(rollerData.Home, rollerData.DA)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(rb, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(rollbackPath(rollerData.Home), b, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	// the namespace is kept so the rollapp data stays in a single namespace
+	if target.Backend == consts.Celestia && target.NamespaceID == "" {
+		for _, ns := range []string{rollerData.DA.NamespaceID, rb.Dymint["namespace_id"]} {
+			if celestia.ValidateNamespaceID(ns) == nil {
+				target.NamespaceID = ns
+				break
+			}
+		}
+	}
+
+	rollerData.DA = target
+	err = roller.WriteConfig(rollerData)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonic, err := damanager.InitializeLightNodeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the %s light node: %w", target.ID, err)
+	}
+
+	err = sequencer.UpdateDymintDAConfig(rollerData)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Backend == consts.Celestia {
+		err = initCelestia(rollerData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if target.Backend == consts.Local {
+		return nil, nil
+	}
+
+	daAddress, err := damanager.GetDAAccountAddress()
+	if err != nil {
+		return nil, err
+	}
+	if daAddress == nil {
+		return nil, nil
+	}
+
+	return &keys.KeyInfo{
+		Name:     damanager.GetKeyName(),
+		Address:  daAddress.Address,
+		Mnemonic: mnemonic,
+	}, nil
+}
+
+// initCelestia makes the new light node sync from the latest celestia block,
+// the rollapp has no history on the new network, and points dymint at it
+func initCelestia(rollerData roller.RollappConfig) error {
+	height, hash, err := lightclient.TrustedBlock(rollerData, lightclient.TrustedFromLatest)
+	if err != nil {
+		return err
+	}
+	err = lightclient.UpdateConfig(lightclient.ConfigFilePath(rollerData.Home), hash, height)
+	if err != nil {
+		return err
+	}
+
+	c := celestia.NewCelestia(rollerData.Home)
+	daConfig, err := c.GetSequencerDAConfig(rollerData.NodeType)
+	if err != nil {
+		return err
+	}
+
+	dymintPath := sequencerutils.GetDymintFilePath(rollerData.Home)
+	for k, v := range map[string]string{
+		"da_layer":     string(consts.Celestia),
+		"namespace_id": c.GetNamespaceID(),
+		"da_config":    daConfig,
+	} {
+		err := tomlconfig.UpdateFieldInFile(dymintPath, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore rolls back the last switch, the light node of the abandoned DA is
+// moved aside so its keys aren't lost
+func Restore(rollerData roller.RollappConfig) (*Rollback, error) {
+	rb, err := LoadRollback(rollerData.Home)
+	if err != nil {
+		return nil, err
+	}
+	if rb == nil {
+		return nil, errors.New("there is no DA switch to roll back")
+	}
+
+	_, err = moveLightNodeAside(rollerData.Home, rollerData.DA)
+	if err != nil {
+		return nil, err
+	}
+	if rb.LightNodeBackup != "" {
+		err = os.Rename(rb.LightNodeBackup, lightNodeDir(rollerData.Home))
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore the light node: %w", err)
+		}
+	}
+
+	rollerData.DA = rb.DA
+	err = roller.WriteConfig(rollerData)
+	if err != nil {
+		return nil, err
+	}
+
+	dymintPath := sequencerutils.GetDymintFilePath(rollerData.Home)
+	for k, v := range rb.Dymint {
+		err := tomlconfig.UpdateFieldInFile(dymintPath, k, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = os.Remove(rollbackPath(rollerData.Home))
+	if err != nil {
+		return nil, err
+	}
+
+	return rb, nil
+}
+
+// moveLightNodeAside moves the light node directory to the da-switch
+// directory and returns its new path, empty when there is no light node
+func moveLightNodeAside(home string, da consts.DaData) (string, error) {
+	_, err := os.Stat(lightNodeDir(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	err = os.MkdirAll(Dir(home), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(
+		Dir(home),
+		fmt.Sprintf(
+			"%s.%s-%s-%d",
+			consts.ConfigDirName.DALightNode,
+			da.Backend,
+			da.ID,
+			time.Now().Unix(),
+		),
+	)
+	err = os.Rename(lightNodeDir(home), dst)
+	if err != nil {
+		return "", fmt.Errorf("failed to move the light node aside: %w", err)
+	}
+
+	return dst, nil
+}
+
+// maxBalanceErrors is the number of consecutive failed balance checks after
+// which WaitForFunding gives up
+const maxBalanceErrors = 5
+
+// WaitForFunding waits until the new DA account holds the minimum balance
+// the light node requires. It gives up after the timeout, a timeout of 0
+// waits indefinitely, or when the balance can't be checked repeatedly
+func WaitForFunding(rollerData roller.RollappConfig, interval, timeout time.Duration) error {
+	damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+	if err != nil {
+		return err
+	}
+
+	var errCount int
+	start := time.Now()
+	for {
+		insufficient, err := damanager.CheckDABalance()
+		if err == nil && len(insufficient) == 0 {
+			return nil
+		}
+
+		if err != nil {
+			errCount++
+			if errCount >= maxBalanceErrors {
+				return fmt.Errorf("failed to check the DA balance %d times in a row: %w", errCount, err)
+			}
+		} else {
+			errCount = 0
+		}
+
+		if timeout > 0 && time.Since(start) > timeout {
+			return fmt.Errorf("the DA account wasn't funded within %s", timeout)
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,382 @@
+package dependencies
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/dependencies/types"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+)
+
+// A bundle is a .tar.gz archive with every binary necessary to run a rollapp
+// node, used to install roller dependencies on hosts without network access:
+//
+//	bundle.json
+//	bin/<binary>
+const (
+	bundleManifestFileName = "bundle.json"
+	bundleBinDir           = "bin"
+)
+
+type BundleBinary struct {
+	Name       string `json:"name"`
+	Dependency string `json:"dependency"`
+	Version    string `json:"version"`
+	SHA256     string `json:"sha256"`
+}
+
+type BundleManifest struct {
+	RollappID string         `json:"rollapp_id"`
+	OS        string         `json:"os"`
+	Arch      string         `json:"arch"`
+	CreatedAt time.Time      `json:"created_at"`
+	Binaries  []BundleBinary `json:"binaries"`
+}
+
+// CreateBundle builds or downloads the binaries required by the rollapp and
+// writes them together with their checksums into a bundle at output. The
+// bundle targets the os and architecture of the host it's created on
+func CreateBundle(
+	raResp rollapp.ShowRollappResponse,
+	pins map[string]string,
+	output string,
+) (*BundleManifest, error) {
+	stagingDir, err := os.MkdirTemp(os.TempDir(), "roller-bundle")
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer os.RemoveAll(stagingDir)
+
+	binDir := filepath.Join(stagingDir, bundleBinDir)
+	err = os.MkdirAll(binDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	buildableDeps, goreleaserDeps, err := ResolveDependencies(false, raResp, pins)
+	if err != nil {
+		return nil, err
+	}
+	goreleaserDeps["dymension"] = DymdDependency(pins)
+
+	defer func() {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		_ = os.Chdir(dir)
+	}()
+
+	bm := &BundleManifest{
+		RollappID: raResp.Rollapp.RollappId,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	install := func(k string, dep types.Dependency, fromRepo bool) error {
+		// install into the staging directory instead of the executable paths
+		dep.Binaries = append([]types.BinaryPathPair{}, dep.Binaries...)
+		for i, bin := range dep.Binaries {
+			dep.Binaries[i].BinaryDestination = filepath.Join(
+				binDir,
+				filepath.Base(bin.BinaryDestination),
+			)
+		}
+
+		var err error
+		if fromRepo {
+			err = InstallBinaryFromRepo(dep, k)
+		} else {
+			err = InstallBinaryFromRelease(dep)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to build binary %s: %w", k, err)
+		}
+
+		for _, bin := range dep.Binaries {
+			sum, err := FileSHA256(bin.BinaryDestination)
+			if err != nil {
+				return err
+			}
+
+			bm.Binaries = append(
+				bm.Binaries, BundleBinary{
+					Name:       filepath.Base(bin.BinaryDestination),
+					Dependency: dep.DependencyName,
+					Version:    dep.Release,
+					SHA256:     sum,
+				},
+			)
+		}
+
+		return nil
+	}
+
+	for k, dep := range goreleaserDeps {
+		err = install(k, dep, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for k, dep := range buildableDeps {
+		err = install(k, dep, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.MarshalIndent(bm, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(stagingDir, bundleManifestFileName), b, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeBundle(stagingDir, bm, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return bm, nil
+}
+
+func writeBundle(stagingDir string, bm *BundleManifest, output string) error {
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+
+	files := []string{bundleManifestFileName}
+	for _, b := range bm.Binaries {
+		files = append(files, filepath.Join(bundleBinDir, b.Name))
+	}
+
+	for _, name := range files {
+		err = addToTar(tw, filepath.Join(stagingDir, name), name)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return gzw.Close()
+}
+
+func addToTar(tw *tar.Writer, path, name string) error {
+	// nolint: gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Uname, header.Gname = "", ""
+	header.Uid, header.Gid = 0, 0
+
+	err = tw.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// StageBinary installs a prebuilt rollapp binary of the version as a managed
+// upgrade instead of replacing the rollapp executable
+type StageBinary func(binary, version string) error
+
+// InstallBundle installs the binaries of a bundle into the executable paths
+// after verifying their checksums. No network access is required. When
+// stageRollapp is set, the rollapp binary is staged with it instead
+func InstallBundle(path string, stageRollapp StageBinary) (*BundleManifest, error) {
+	extractDir, err := os.MkdirTemp(os.TempDir(), "roller-bundle")
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer os.RemoveAll(extractDir)
+
+	err = extractBundle(path, extractDir)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(extractDir, bundleManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle, %s is missing: %w", bundleManifestFileName, err)
+	}
+
+	var bm BundleManifest
+	err = json.Unmarshal(b, &bm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+
+	if bm.OS != runtime.GOOS || bm.Arch != runtime.GOARCH {
+		return nil, fmt.Errorf(
+			"the bundle was created for %s/%s, this host is %s/%s",
+			bm.OS,
+			bm.Arch,
+			runtime.GOOS,
+			runtime.GOARCH,
+		)
+	}
+
+	tracked := TrackedExecutables()
+	// verify everything before installing anything
+	for _, bin := range bm.Binaries {
+		if _, ok := tracked[bin.Name]; !ok {
+			return nil, fmt.Errorf("unknown binary %s in the bundle", bin.Name)
+		}
+
+		sum, err := FileSHA256(filepath.Join(extractDir, bundleBinDir, bin.Name))
+		if err != nil {
+			return nil, fmt.Errorf("%s is missing from the bundle: %w", bin.Name, err)
+		}
+		if sum != bin.SHA256 {
+			return nil, fmt.Errorf(
+				"%s checksum mismatch, have: %s, want: %s",
+				bin.Name,
+				sum,
+				bin.SHA256,
+			)
+		}
+	}
+
+	for _, bin := range bm.Binaries {
+		dest := tracked[bin.Name]
+
+		if dest == consts.Executables.RollappEVM && stageRollapp != nil {
+			err := stageRollapp(filepath.Join(extractDir, bundleBinDir, bin.Name), bin.Version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stage %s: %w", bin.Name, err)
+			}
+			continue
+		}
+
+		err := checkNotManaged(dest)
+		if err != nil {
+			return nil, err
+		}
+
+		c := exec.Command("sudo", "mkdir", "-p", filepath.Dir(dest))
+		_, err = bash.ExecCommandWithStdout(c)
+		if err != nil {
+			return nil, err
+		}
+
+		c = exec.Command(
+			"sudo", "install", "-m", "0755",
+			filepath.Join(extractDir, bundleBinDir, bin.Name),
+			dest,
+		)
+		_, err = bash.ExecCommandWithStdout(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to install %s: %w", bin.Name, err)
+		}
+
+		err = recordInstall(
+			types.Dependency{DependencyName: bin.Dependency, Release: bin.Version},
+			types.BinaryPathPair{Binary: bin.Name, BinaryDestination: dest},
+			"",
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &bm, nil
+}
+
+func extractBundle(path, destDir string) error {
+	// nolint: gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read the bundle: %w", err)
+	}
+	// nolint: errcheck
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the bundle: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		target := filepath.Join(destDir, filepath.Clean(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(filepath.Separator)) {
+			return errors.New("invalid path in the bundle: " + header.Name)
+		}
+
+		err = os.MkdirAll(filepath.Dir(target), 0o755)
+		if err != nil {
+			return err
+		}
+
+		// nolint: gosec
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+		if err != nil {
+			return err
+		}
+
+		// nolint: gosec
+		_, err = io.Copy(out, tr)
+		_ = out.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
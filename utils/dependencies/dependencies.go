@@ -24,7 +24,35 @@ import (
 	"github.com/dymensionxyz/roller/utils/rollapp"
 )
 
-func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
+// DefaultReleases are the versions installed for the binaries that aren't
+// defined by the rollapp, keyed by the binary name
+var DefaultReleases = map[string]string{
+	"celestia":      "v0.18.2-mocha",
+	"cel-key":       "v0.18.2-mocha",
+	"celestia-appd": "v2.1.2",
+	"eibc-client":   "v1.1.4-roller",
+	"rly":           "v0.4.0-v2.5.2-relayer-pg-roller",
+	"dymd":          "v3.1.0-pg07",
+}
+
+// ErrManagedBinary is returned when an install would replace an executable
+// that is a symlink managed by roller upgrades
+var ErrManagedBinary = errors.New("the executable is managed by roller upgrades, stage the binary instead")
+
+// StageDependency installs the rollapp dependency as a managed upgrade
+// instead of replacing the rollapp executable
+type StageDependency func(dep types.Dependency) error
+
+// InstallBinaries installs the binaries necessary to run the rollapp. Pinned
+// versions, keyed by the binary name, take precedence over the defaults and
+// the rollapp version from the genesis file. When stageRollapp is set, the
+// rollapp binary is staged with it instead of being installed
+func InstallBinaries(
+	withMockDA bool,
+	raResp rollapp.ShowRollappResponse,
+	pins map[string]string,
+	stageRollapp StageDependency,
+) (
 	map[string]types.Dependency,
 	map[string]types.Dependency,
 	error,
@@ -36,6 +64,82 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 		return nil, nil, errors.New(errMsg)
 	}
 
+	if withMockDA {
+		err = installLibWasm()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	buildableDeps, goreleaserDeps, err := ResolveDependencies(withMockDA, raResp, pins)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer func() {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		_ = os.Chdir(dir)
+	}()
+
+	for k, dep := range goreleaserDeps {
+		if IsInstalled(dep) {
+			pterm.Info.Printf("[%s] %s is already installed\n", dep.DependencyName, dep.Release)
+			continue
+		}
+
+		if k == "rollapp" && stageRollapp != nil {
+			err := stageRollapp(dep)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to stage binary %s: %w", k, err)
+			}
+			continue
+		}
+
+		err := InstallBinaryFromRelease(dep)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to build binary %s: %v", k, err)
+			return nil, nil, errors.New(errMsg)
+		}
+	}
+
+	for k, dep := range buildableDeps {
+		if IsInstalled(dep) {
+			pterm.Info.Printf("[%s] %s is already installed\n", dep.DependencyName, dep.Release)
+			continue
+		}
+
+		if k == "rollapp" && stageRollapp != nil {
+			err := stageRollapp(dep)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to stage binary %s: %w", k, err)
+			}
+			continue
+		}
+
+		err := InstallBinaryFromRepo(dep, k)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to build binary %s: %v", k, err)
+			return nil, nil, errors.New(errMsg)
+		}
+	}
+
+	return buildableDeps, goreleaserDeps, nil
+}
+
+// ResolveDependencies returns the dependencies built from source and the ones
+// downloaded from releases for the rollapp, with the pins applied
+func ResolveDependencies(
+	withMockDA bool,
+	raResp rollapp.ShowRollappResponse,
+	pins map[string]string,
+) (
+	map[string]types.Dependency,
+	map[string]types.Dependency,
+	error,
+) {
 	genesisTmpDir, err := os.MkdirTemp(os.TempDir(), "genesis-file")
 	if err != nil {
 		return nil, nil, err
@@ -67,14 +171,6 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 		pterm.Info.Println("RollApp binary version from the genesis file : ", raBinCommit)
 	}
 
-	defer func() {
-		dir, err := os.UserHomeDir()
-		if err != nil {
-			return
-		}
-		_ = os.Chdir(dir)
-	}()
-
 	buildableDeps := map[string]types.Dependency{}
 
 	if !withMockDA {
@@ -83,7 +179,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 			RepositoryOwner: "celestiaorg",
 			RepositoryName:  "celestia-node",
 			RepositoryUrl:   "https://github.com/celestiaorg/celestia-node.git",
-			Release:         "v0.18.2-mocha",
+			Release:         DefaultReleases["celestia"],
 			Binaries: []types.BinaryPathPair{
 				{
 					Binary:            "./build/celestia",
@@ -104,48 +200,16 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 			},
 		}
 
-		if raVmType == "evm" {
-			buildableDeps["rollapp"] = types.Dependency{
-				DependencyName:  "rollapp",
-				RepositoryOwner: "dymensionxyz",
-				RepositoryName:  "rollapp-evm",
-				RepositoryUrl:   "https://github.com/dymensionxyz/rollapp-evm.git",
-				Release:         raBinCommit,
-				Binaries: []types.BinaryPathPair{
-					{
-						Binary:            "./build/rollapp-evm",
-						BinaryDestination: consts.Executables.RollappEVM,
-						BuildCommand: exec.Command(
-							"make",
-							"build",
-							fmt.Sprintf("BECH32_PREFIX=%s", raBech32Prefix),
-						),
-					},
-				},
-				PersistFiles: []types.PersistFile{},
-			}
-		} else if raVmType == "wasm" {
-			buildableDeps["rollapp"] = types.Dependency{
-				DependencyName:  "rollapp",
-				RepositoryOwner: "dymensionxyz",
-				RepositoryName:  "rollapp-wasm",
-				RepositoryUrl:   "https://github.com/dymensionxyz/rollapp-wasm.git",
-				Release:         raBinCommit,
-				Binaries: []types.BinaryPathPair{
-					{
-						Binary:            "./build/rollapp-wasm",
-						BinaryDestination: consts.Executables.RollappEVM,
-						BuildCommand: exec.Command(
-							"make",
-							"build",
-							fmt.Sprintf("BECH32_PREFIX=%s", raBech32Prefix),
-						),
-					},
-				},
-			}
-		} else {
-			return nil, nil, fmt.Errorf("RollApp VM '%s' type is not supported", raVmType)
+		raDep, err := GetRollappDependency(
+			raVmType,
+			raBinCommit,
+			raBech32Prefix,
+			consts.Executables.RollappEVM,
+		)
+		if err != nil {
+			return nil, nil, err
 		}
+		buildableDeps["rollapp"] = raDep
 	}
 
 	goreleaserDeps := map[string]types.Dependency{}
@@ -155,7 +219,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 			"celestia-app": {
 				DependencyName: "celestia-app",
 				RepositoryUrl:  "https://github.com/celestiaorg/celestia-app",
-				Release:        "v2.1.2",
+				Release:        DefaultReleases["celestia-appd"],
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "celestia-appd",
@@ -172,7 +236,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 				RepositoryOwner: "artemijspavlovs",
 				RepositoryName:  "eibc-client",
 				RepositoryUrl:   "https://github.com/artemijspavlovs/eibc-client",
-				Release:         "v1.1.4-roller",
+				Release:         DefaultReleases["eibc-client"],
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "eibc-client",
@@ -185,7 +249,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 				RepositoryOwner: "artemijspavlovs",
 				RepositoryName:  "go-relayer",
 				RepositoryUrl:   "https://github.com/artemijspavlovs/go-relayer",
-				Release:         "v0.4.0-v2.5.2-relayer-pg-roller",
+				Release:         DefaultReleases["rly"],
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "rly",
@@ -201,43 +265,6 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 	}
 
 	if withMockDA {
-		// @20240913 libwasm is necessary on the host VM to be able to run the rollapp binary
-		var outputPath string
-		var libName string
-		libVersion := "v1.2.3"
-
-		if runtime.GOOS == "linux" {
-			outputPath = "/usr/lib"
-			if runtime.GOARCH == "arm64" {
-				libName = "libwasmvm.aarch64.so"
-			} else if runtime.GOARCH == "amd64" {
-				libName = "libwasmvm.x86_64.so"
-			}
-		} else if runtime.GOOS == "darwin" {
-			outputPath = "/usr/local/lib"
-			libName = "libwasmvm.dylib"
-		} else {
-			return nil, nil, errors.New("unsupported OS")
-		}
-
-		downloadPath := fmt.Sprintf(
-			"https://github.com/CosmWasm/wasmvm/releases/download/%s/%s",
-			libVersion,
-			libName,
-		)
-
-		fsc := exec.Command("sudo", "mkdir", "-p", outputPath)
-		_, err := bash.ExecCommandWithStdout(fsc)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		c := exec.Command("sudo", "wget", "-O", filepath.Join(outputPath, libName), downloadPath)
-		_, err = bash.ExecCommandWithStdout(c)
-		if err != nil {
-			return nil, nil, err
-		}
-
 		if raVmType == "evm" {
 			goreleaserDeps["rollapp"] = types.Dependency{
 				DependencyName:  "rollapp-evm",
@@ -267,27 +294,107 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse) (
 				},
 			}
 		}
-
 	}
 
-	for k, dep := range goreleaserDeps {
-		err := InstallBinaryFromRelease(dep)
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to build binary %s: %v", k, err)
-			return nil, nil, errors.New(errMsg)
+	applyPins(buildableDeps, pins)
+	applyPins(goreleaserDeps, pins)
+
+	return buildableDeps, goreleaserDeps, nil
+}
+
+// installLibWasm installs libwasmvm
+func installLibWasm() error {
+	// @20240913 libwasm is necessary on the host VM to be able to run the rollapp binary
+	var outputPath string
+	var libName string
+	libVersion := "v1.2.3"
+
+	if runtime.GOOS == "linux" {
+		outputPath = "/usr/lib"
+		if runtime.GOARCH == "arm64" {
+			libName = "libwasmvm.aarch64.so"
+		} else if runtime.GOARCH == "amd64" {
+			libName = "libwasmvm.x86_64.so"
 		}
+	} else if runtime.GOOS == "darwin" {
+		outputPath = "/usr/local/lib"
+		libName = "libwasmvm.dylib"
+	} else {
+		return errors.New("unsupported OS")
+	}
+
+	downloadPath := fmt.Sprintf(
+		"https://github.com/CosmWasm/wasmvm/releases/download/%s/%s",
+		libVersion,
+		libName,
+	)
 
+	fsc := exec.Command("sudo", "mkdir", "-p", outputPath)
+	_, err := bash.ExecCommandWithStdout(fsc)
+	if err != nil {
+		return err
 	}
 
-	for k, dep := range buildableDeps {
-		err := InstallBinaryFromRepo(dep, k)
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to build binary %s: %v", k, err)
-			return nil, nil, errors.New(errMsg)
+	c := exec.Command("sudo", "wget", "-O", filepath.Join(outputPath, libName), downloadPath)
+	_, err = bash.ExecCommandWithStdout(c)
+	return err
+}
+
+// applyPins overrides the release of every dependency that produces a pinned
+// binary
+func applyPins(deps map[string]types.Dependency, pins map[string]string) {
+	for k, dep := range deps {
+		for _, bin := range dep.Binaries {
+			v, ok := pins[filepath.Base(bin.BinaryDestination)]
+			if !ok || v == "" || v == dep.Release {
+				continue
+			}
+
+			pterm.Warning.Printf(
+				"[%s] using the pinned version %s instead of %s\n",
+				dep.DependencyName,
+				v,
+				dep.Release,
+			)
+			dep.Release = v
+			deps[k] = dep
+			break
 		}
 	}
+}
 
-	return buildableDeps, goreleaserDeps, nil
+// GetRollappDependency returns the rollapp repository and build instructions
+// for the vm type, the binary is installed to the provided destination
+func GetRollappDependency(vmType, release, bech32Prefix, dest string) (types.Dependency, error) {
+	var repo string
+	switch vmType {
+	case "evm":
+		repo = "rollapp-evm"
+	case "wasm":
+		repo = "rollapp-wasm"
+	default:
+		return types.Dependency{}, fmt.Errorf("RollApp VM '%s' type is not supported", vmType)
+	}
+
+	return types.Dependency{
+		DependencyName:  "rollapp",
+		RepositoryOwner: "dymensionxyz",
+		RepositoryName:  repo,
+		RepositoryUrl:   fmt.Sprintf("https://github.com/dymensionxyz/%s.git", repo),
+		Release:         release,
+		Binaries: []types.BinaryPathPair{
+			{
+				Binary:            fmt.Sprintf("./build/%s", repo),
+				BinaryDestination: dest,
+				BuildCommand: exec.Command(
+					"make",
+					"build",
+					fmt.Sprintf("BECH32_PREFIX=%s", bech32Prefix),
+				),
+			},
+		},
+		PersistFiles: []types.PersistFile{},
+	}, nil
 }
 
 func InstallBinaryFromRepo(dep types.Dependency, td string) error {
@@ -347,6 +454,20 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 		),
 	)
 
+	var commit string
+	out, err := bash.ExecCommandWithStdout(exec.Command("git", "rev-parse", "HEAD"))
+	if err == nil {
+		commit = strings.TrimSpace(out.String())
+	}
+
+	// commits, e.g. the rollapp version of the genesis file, are recorded
+	// with the nearest release tag so they can be placed in the version index
+	release := dep.Release
+	out, err = bash.ExecCommandWithStdout(exec.Command("git", "describe", "--tags", "--abbrev=0"))
+	if err == nil {
+		release = strings.TrimSpace(out.String())
+	}
+
 	// Build the binary
 	for _, binary := range dep.Binaries {
 		_, err := bash.ExecCommandWithStdout(binary.BuildCommand)
@@ -355,6 +476,12 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 			return err
 		}
 
+		err = checkNotManaged(binary.BinaryDestination)
+		if err != nil {
+			spinner.Fail("failed to install")
+			return err
+		}
+
 		c := exec.Command("sudo", "mv", binary.Binary, binary.BinaryDestination)
 		if _, err := bash.ExecCommandWithStdout(c); err != nil {
 			spinner.Fail("failed to install")
@@ -363,13 +490,56 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 		spinner.UpdateText(
 			fmt.Sprintf("[%s] finishing installation", filepath.Base(binary.BinaryDestination)),
 		)
+
+		err = recordBuild(dep, binary, commit, release)
+		if err != nil {
+			spinner.Fail("failed to record the installation")
+			return err
+		}
 	}
 
 	spinner.Success(fmt.Sprintf("[%s] installed\n", dep.DependencyName))
 	return nil
 }
 
+// checkNotManaged refuses to replace a symlinked executable, roller upgrades
+// link the rollapp executable to the active upgrade
+func checkNotManaged(dest string) error {
+	fi, err := os.Lstat(dest)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%s: %w", dest, ErrManagedBinary)
+	}
+
+	return nil
+}
+
+// InstallDependency installs the dependency from its release when its
+// binaries have no build command, and builds it from the repository otherwise
+func InstallDependency(dep types.Dependency) error {
+	for _, b := range dep.Binaries {
+		if b.BuildCommand == nil {
+			return InstallBinaryFromRelease(dep)
+		}
+	}
+
+	return InstallBinaryFromRepo(dep, dep.DependencyName)
+}
+
 func InstallBinaryFromRelease(dep types.Dependency) error {
+	for _, binary := range dep.Binaries {
+		err := checkNotManaged(binary.BinaryDestination)
+		if err != nil {
+			return err
+		}
+	}
+
 	spinner, _ := pterm.DefaultSpinner.Start(
 		fmt.Sprintf("[%s] installing", dep.DependencyName),
 	)
@@ -411,6 +581,14 @@ func InstallBinaryFromRelease(dep types.Dependency) error {
 	}
 	spinner.UpdateText(fmt.Sprintf("[%s] downloaded successfully", dep.DependencyName))
 
+	for _, binary := range dep.Binaries {
+		err = recordInstall(dep, binary, "")
+		if err != nil {
+			spinner.Fail("failed to record the installation")
+			return err
+		}
+	}
+
 	spinner.Success(fmt.Sprintf("[%s] installed\n", dep.DependencyName))
 	return nil
 }
@@ -466,6 +644,31 @@ func DownloadRelease(
 	return nil
 }
 
+// DymdDependency returns the dymd release installed for the public hubs
+func DymdDependency(pins map[string]string) types.Dependency {
+	dep := types.Dependency{
+		DependencyName:  "dymension",
+		RepositoryOwner: "dymensionxyz",
+		RepositoryName:  "dymension",
+		RepositoryUrl:   "https://github.com/artemijspavlovs/dymension",
+		Release:         DefaultReleases["dymd"],
+		Binaries: []types.BinaryPathPair{
+			{
+				Binary:            "dymd",
+				BinaryDestination: consts.Executables.Dymension,
+				BuildCommand:      exec.Command("make", "build"),
+			},
+		},
+		PersistFiles: []types.PersistFile{},
+	}
+
+	if v, ok := pins["dymd"]; ok && v != "" {
+		dep.Release = v
+	}
+
+	return dep
+}
+
 func InstallCustomDymdVersion() error {
 	dymdCommit, _ := pterm.DefaultInteractiveTextInput.WithDefaultText(
 		"provide dymensionxyz/dymension commit to build (example: 2cd612aaa6c21b473dbbb7dca9fd03b5aaae6583)",
@@ -0,0 +1,351 @@
+package dependencies
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/dependencies/types"
+)
+
+// Every binary roller installs into one of the executable paths is recorded
+// in the manifest and copied into a versioned cache, so the installation can
+// be verified and the previous version restored:
+//
+//	<roller_bins>/manifest.json
+//	<roller_bins>/versions/<binary>/<version>/<binary>
+const manifestFileName = "manifest.json"
+
+type BinaryVersion struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	// Release is the release tag of the binary, builds of a commit record the
+	// nearest tag preceding it
+	Release     string    `json:"release,omitempty"`
+	SHA256      string    `json:"sha256"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+type InstalledBinary struct {
+	Name       string         `json:"name"`
+	Dependency string         `json:"dependency"`
+	Path       string         `json:"path"`
+	Current    BinaryVersion  `json:"current"`
+	Previous   *BinaryVersion `json:"previous,omitempty"`
+}
+
+type Manifest struct {
+	Binaries map[string]InstalledBinary `json:"binaries"`
+}
+
+// VerifyResult is the outcome of comparing an installed binary with the
+// checksum recorded in the manifest
+type VerifyResult struct {
+	Binary   InstalledBinary
+	SHA256   string
+	Missing  bool
+	Modified bool
+}
+
+func ManifestPath() string {
+	return filepath.Join(consts.InternalBinsDir, manifestFileName)
+}
+
+func cachedBinaryPath(name, version string) string {
+	return filepath.Join(
+		consts.InternalBinsDir,
+		"versions",
+		name,
+		strings.ReplaceAll(version, string(os.PathSeparator), "_"),
+		name,
+	)
+}
+
+// TrackedExecutables returns the executables installed by roller, keyed by
+// the binary name
+func TrackedExecutables() map[string]string {
+	tracked := map[string]string{}
+	for _, p := range []string{
+		consts.Executables.RollappEVM,
+		consts.Executables.Celestia,
+		consts.Executables.CelKey,
+		consts.Executables.CelestiaApp,
+		consts.Executables.Relayer,
+		consts.Executables.Dymension,
+		consts.Executables.Eibc,
+	} {
+		tracked[filepath.Base(p)] = p
+	}
+
+	return tracked
+}
+
+func isTracked(path string) bool {
+	p, ok := TrackedExecutables()[filepath.Base(path)]
+	return ok && p == path
+}
+
+func LoadManifest() (*Manifest, error) {
+	m := &Manifest{Binaries: map[string]InstalledBinary{}}
+
+	b, err := os.ReadFile(ManifestPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return m, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid binaries manifest %s: %w", ManifestPath(), err)
+	}
+	if m.Binaries == nil {
+		m.Binaries = map[string]InstalledBinary{}
+	}
+
+	return m, nil
+}
+
+// Save writes the manifest through a temporary file, the binaries directory
+// is owned by root
+func (m *Manifest) Save() error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(os.TempDir(), manifestFileName)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(b)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	c := exec.Command("sudo", "install", "-m", "0644", tmp.Name(), ManifestPath())
+	_, err = bash.ExecCommandWithStdout(c)
+	return err
+}
+
+// List returns the installed binaries ordered by name
+func (m *Manifest) List() []InstalledBinary {
+	var bins []InstalledBinary
+	for _, b := range m.Binaries {
+		bins = append(bins, b)
+	}
+
+	sort.Slice(
+		bins, func(i, j int) bool {
+			return bins[i].Name < bins[j].Name
+		},
+	)
+
+	return bins
+}
+
+// recordInstall adds a freshly installed binary to the manifest and the
+// versioned cache. The version that was installed before is kept as the
+// rollback target
+func recordInstall(dep types.Dependency, bin types.BinaryPathPair, commit string) error {
+	return recordBuild(dep, bin, commit, dep.Release)
+}
+
+// recordBuild records an installed binary together with the release tag it
+// was built from
+func recordBuild(dep types.Dependency, bin types.BinaryPathPair, commit, release string) error {
+	if !isTracked(bin.BinaryDestination) {
+		return nil
+	}
+
+	name := filepath.Base(bin.BinaryDestination)
+	sum, err := FileSHA256(bin.BinaryDestination)
+	if err != nil {
+		return err
+	}
+
+	cached := cachedBinaryPath(name, dep.Release)
+	c := exec.Command("sudo", "mkdir", "-p", filepath.Dir(cached))
+	_, err = bash.ExecCommandWithStdout(c)
+	if err != nil {
+		return err
+	}
+
+	c = exec.Command("sudo", "cp", bin.BinaryDestination, cached)
+	_, err = bash.ExecCommandWithStdout(c)
+	if err != nil {
+		return fmt.Errorf("failed to cache %s: %w", name, err)
+	}
+
+	m, err := LoadManifest()
+	if err != nil {
+		return err
+	}
+
+	ib := m.Binaries[name]
+	if ib.Current.Version != "" && ib.Current.Version != dep.Release {
+		prev := ib.Current
+		ib.Previous = &prev
+	}
+
+	ib.Name = name
+	ib.Dependency = dep.DependencyName
+	ib.Path = bin.BinaryDestination
+	ib.Current = BinaryVersion{
+		Version:     dep.Release,
+		Commit:      commit,
+		Release:     release,
+		SHA256:      sum,
+		InstalledAt: time.Now().UTC(),
+	}
+	m.Binaries[name] = ib
+
+	return m.Save()
+}
+
+// InstalledRelease returns the release tag of the installed binary, falling
+// back to the installed version for binaries recorded without one
+func InstalledRelease(name string) (string, error) {
+	m, err := LoadManifest()
+	if err != nil {
+		return "", err
+	}
+
+	ib, ok := m.Binaries[name]
+	if !ok {
+		return "", fmt.Errorf("%s is not installed by roller", name)
+	}
+	if ib.Current.Release != "" {
+		return ib.Current.Release, nil
+	}
+
+	return ib.Current.Version, nil
+}
+
+// Verify compares the checksums of the installed binaries with the manifest
+func (m *Manifest) Verify() ([]VerifyResult, error) {
+	var results []VerifyResult
+	for _, b := range m.List() {
+		r := VerifyResult{Binary: b}
+
+		sum, err := FileSHA256(b.Path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			r.Missing = true
+		} else {
+			r.SHA256 = sum
+			r.Modified = sum != b.Current.SHA256
+		}
+
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// Rollback restores the previously installed version of the binary from the
+// versioned cache
+func Rollback(name string) (*InstalledBinary, error) {
+	m, err := LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	ib, ok := m.Binaries[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not installed by roller", name)
+	}
+	if ib.Previous == nil {
+		return nil, fmt.Errorf("no previous version of %s is available", name)
+	}
+
+	cached := cachedBinaryPath(name, ib.Previous.Version)
+	sum, err := FileSHA256(cached)
+	if err != nil {
+		return nil, fmt.Errorf("cached %s %s is not available: %w", name, ib.Previous.Version, err)
+	}
+	if sum != ib.Previous.SHA256 {
+		return nil, fmt.Errorf(
+			"cached %s %s checksum mismatch, have: %s, want: %s",
+			name,
+			ib.Previous.Version,
+			sum,
+			ib.Previous.SHA256,
+		)
+	}
+
+	c := exec.Command("sudo", "cp", cached, ib.Path)
+	_, err = bash.ExecCommandWithStdout(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+	}
+
+	current := ib.Current
+	ib.Current = *ib.Previous
+	ib.Previous = &current
+	m.Binaries[name] = ib
+
+	return &ib, m.Save()
+}
+
+func FileSHA256(path string) (string, error) {
+	// nolint:gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	// nolint:errcheck
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// IsInstalled reports whether all binaries of the dependency are installed at
+// the dependency release and match the checksums recorded in the manifest
+func IsInstalled(dep types.Dependency) bool {
+	m, err := LoadManifest()
+	if err != nil {
+		return false
+	}
+
+	for _, bin := range dep.Binaries {
+		ib, ok := m.Binaries[filepath.Base(bin.BinaryDestination)]
+		if !ok || ib.Path != bin.BinaryDestination || ib.Current.Version != dep.Release {
+			return false
+		}
+
+		sum, err := FileSHA256(bin.BinaryDestination)
+		if err != nil || sum != ib.Current.SHA256 {
+			return false
+		}
+	}
+
+	return len(dep.Binaries) > 0
+}
@@ -0,0 +1,301 @@
+package dymint
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
+)
+
+// Field numbers of the dymint batch protobuf messages (types/pb/dymint)
+const (
+	batchStartHeightField = 1
+	batchEndHeightField   = 2
+	batchBlocksField      = 3
+	batchCommitsField     = 4
+
+	blockHeaderField = 1
+	blockDataField   = 2
+
+	headerHeightField          = 3
+	headerTimeField            = 4
+	headerLastHeaderHashField  = 5
+	headerDataHashField        = 7
+	headerAppHashField         = 9
+	headerProposerAddressField = 11
+	headerChainIDField         = 13
+
+	dataTxsField = 1
+
+	commitHeightField     = 1
+	commitHeaderHashField = 2
+)
+
+// BatchBlock is the part of a dymint block header relevant for inspecting a
+// batch
+type BatchBlock struct {
+	Height          uint64
+	Time            time.Time
+	ChainID         string
+	LastHeaderHash  []byte
+	DataHash        []byte
+	AppHash         []byte
+	ProposerAddress []byte
+	NumTxs          int
+	// HeaderHash is taken from the commit of the block
+	HeaderHash []byte
+}
+
+type Batch struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Blocks      []BatchBlock
+}
+
+type batchCommit struct {
+	height     uint64
+	headerHash []byte
+}
+
+// DecodeBatch decodes a dymint batch as it's submitted to the DA
+func DecodeBatch(data []byte) (*Batch, error) {
+	b := &Batch{}
+	var commits []batchCommit
+
+	err := walkFields(
+		data, func(num protowire.Number, v uint64, raw []byte) error {
+			switch num {
+			case batchStartHeightField:
+				b.StartHeight = v
+			case batchEndHeightField:
+				b.EndHeight = v
+			case batchBlocksField:
+				block, err := decodeBlock(raw)
+				if err != nil {
+					return err
+				}
+				b.Blocks = append(b.Blocks, *block)
+			case batchCommitsField:
+				c, err := decodeCommit(raw)
+				if err != nil {
+					return err
+				}
+				commits = append(commits, *c)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dymint batch: %w", err)
+	}
+
+	for i := range b.Blocks {
+		if i < len(commits) && commits[i].height == b.Blocks[i].Height {
+			b.Blocks[i].HeaderHash = commits[i].headerHash
+		}
+	}
+
+	return b, nil
+}
+
+func decodeBlock(data []byte) (*BatchBlock, error) {
+	block := &BatchBlock{}
+
+	err := walkFields(
+		data, func(num protowire.Number, _ uint64, raw []byte) error {
+			switch num {
+			case blockHeaderField:
+				return decodeHeader(raw, block)
+			case blockDataField:
+				return walkFields(
+					raw, func(num protowire.Number, _ uint64, _ []byte) error {
+						if num == dataTxsField {
+							block.NumTxs++
+						}
+						return nil
+					},
+				)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func decodeHeader(data []byte, block *BatchBlock) error {
+	return walkFields(
+		data, func(num protowire.Number, v uint64, raw []byte) error {
+			switch num {
+			case headerHeightField:
+				block.Height = v
+			case headerTimeField:
+				// nolint: gosec
+				block.Time = time.Unix(0, int64(v)).UTC()
+			case headerLastHeaderHashField:
+				block.LastHeaderHash = raw
+			case headerDataHashField:
+				block.DataHash = raw
+			case headerAppHashField:
+				block.AppHash = raw
+			case headerProposerAddressField:
+				block.ProposerAddress = raw
+			case headerChainIDField:
+				block.ChainID = string(raw)
+			}
+			return nil
+		},
+	)
+}
+
+func decodeCommit(data []byte) (*batchCommit, error) {
+	c := &batchCommit{}
+
+	err := walkFields(
+		data, func(num protowire.Number, v uint64, raw []byte) error {
+			switch num {
+			case commitHeightField:
+				c.height = v
+			case commitHeaderHashField:
+				c.headerHash = raw
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// walkFields calls fn for every varint and length delimited field of a
+// protobuf message, other wire types are skipped
+func walkFields(data []byte, fn func(num protowire.Number, v uint64, raw []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var v uint64
+		var raw []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			raw, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+
+		err := fn(num, v, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyBatch compares a decoded batch with the state update posted to the
+// hub and returns every mismatch found
+func VerifyBatch(b *Batch, si rollapputils.StateInfo) ([]string, error) {
+	startHeight, err := strconv.ParseUint(si.StartHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state info start height %s: %w", si.StartHeight, err)
+	}
+	numBlocks, err := strconv.ParseUint(si.NumBlocks, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state info number of blocks %s: %w", si.NumBlocks, err)
+	}
+	if len(b.Blocks) == 0 {
+		return nil, errors.New("the batch doesn't contain any block")
+	}
+
+	var mismatches []string
+	if b.StartHeight != startHeight {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("start height: batch %d, hub %d", b.StartHeight, startHeight),
+		)
+	}
+	if b.EndHeight != startHeight+numBlocks-1 {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("end height: batch %d, hub %d", b.EndHeight, startHeight+numBlocks-1),
+		)
+	}
+	if uint64(len(b.Blocks)) != numBlocks {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("number of blocks: batch %d, hub %d", len(b.Blocks), numBlocks),
+		)
+	}
+
+	blocks := map[uint64]BatchBlock{}
+	for i, block := range b.Blocks {
+		blocks[block.Height] = block
+
+		if i == 0 {
+			continue
+		}
+		prev := b.Blocks[i-1]
+		if block.Height != prev.Height+1 {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("block %d follows block %d", block.Height, prev.Height),
+			)
+		}
+		if prev.HeaderHash != nil && !bytes.Equal(block.LastHeaderHash, prev.HeaderHash) {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("block %d doesn't link to the header of block %d", block.Height, prev.Height),
+			)
+		}
+	}
+
+	for _, bd := range si.BDs.BD {
+		h, err := strconv.ParseUint(bd.Height, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block descriptor height %s: %w", bd.Height, err)
+		}
+
+		block, ok := blocks[h]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("block %d is missing from the batch", h))
+			continue
+		}
+		if !bytes.Equal(block.AppHash, bd.StateRoot) {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf(
+					"block %d state root: batch %s, hub %s",
+					h,
+					hex.EncodeToString(block.AppHash),
+					hex.EncodeToString(bd.StateRoot),
+				),
+			)
+		}
+	}
+
+	return mismatches, nil
+}
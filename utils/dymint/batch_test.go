@@ -0,0 +1,423 @@
+package dymint
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
+)
+
+// dymintProto mirrors the messages of dymint's types/pb/dymint/dymint.proto
+// that make up a batch, including fields the decoder ignores
+var dymintProto = &descriptorpb.FileDescriptorProto{
+	Name:    proto.String("dymint/dymint.proto"),
+	Package: proto.String("dymint"),
+	Syntax:  proto.String("proto3"),
+	MessageType: []*descriptorpb.DescriptorProto{
+		message(
+			"Version",
+			field("block", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("app", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+		),
+		message(
+			"Header",
+			field("version", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, false, ".dymint.Version"),
+			field("namespace_id", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("height", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("time", 4, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("last_header_hash", 5, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("last_commit_hash", 6, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("data_hash", 7, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("consensus_hash", 8, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("app_hash", 9, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("last_results_hash", 10, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("proposer_address", 11, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("sequencer_hash", 12, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("chain_id", 13, descriptorpb.FieldDescriptorProto_TYPE_STRING, false, ""),
+		),
+		message(
+			"Commit",
+			field("height", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("header_hash", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, false, ""),
+			field("signatures", 3, descriptorpb.FieldDescriptorProto_TYPE_BYTES, true, ""),
+		),
+		message(
+			"Data",
+			field("txs", 1, descriptorpb.FieldDescriptorProto_TYPE_BYTES, true, ""),
+			field("intermediate_state_roots", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, true, ""),
+		),
+		message(
+			"Block",
+			field("header", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, false, ".dymint.Header"),
+			field("data", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, false, ".dymint.Data"),
+			field("last_commit", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, false, ".dymint.Commit"),
+		),
+		message(
+			"Batch",
+			field("start_height", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("end_height", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT64, false, ""),
+			field("blocks", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, true, ".dymint.Block"),
+			field("commits", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, true, ".dymint.Commit"),
+		),
+	},
+}
+
+func message(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+}
+
+func field(
+	name string,
+	num int32,
+	typ descriptorpb.FieldDescriptorProto_Type,
+	repeated bool,
+	typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(num),
+		Type:     typ.Enum(),
+		Label:    label.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+
+	return f
+}
+
+// testBlock describes a block of the encoded test batch
+type testBlock struct {
+	height         uint64
+	lastHeaderHash []byte
+	headerHash     []byte
+	appHash        []byte
+	txs            int
+}
+
+var testTime = time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func hash(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+// linkedBlocks returns n consecutive blocks from start, every block links to
+// the header of the previous one
+func linkedBlocks(start uint64, n int) []testBlock {
+	blocks := make([]testBlock, n)
+	for i := range blocks {
+		h := start + uint64(i)
+		blocks[i] = testBlock{
+			height:     h,
+			headerHash: hash(byte(h)),
+			appHash:    hash(byte(h) + 0x80),
+			txs:        i,
+		}
+		if i > 0 {
+			blocks[i].lastHeaderHash = blocks[i-1].headerHash
+		}
+	}
+
+	return blocks
+}
+
+// encodeBatch encodes the blocks through the dymint batch message
+func encodeBatch(t *testing.T, start, end uint64, blocks []testBlock) []byte {
+	t.Helper()
+
+	fd, err := protodesc.NewFile(dymintProto, nil)
+	require.NoError(t, err)
+	md := func(name protoreflect.Name) protoreflect.MessageDescriptor {
+		return fd.Messages().ByName(name)
+	}
+	newMsg := func(name protoreflect.Name, fields map[protoreflect.Name]protoreflect.Value) *dynamicpb.Message {
+		m := dynamicpb.NewMessage(md(name))
+		for k, v := range fields {
+			m.Set(md(name).Fields().ByName(k), v)
+		}
+		return m
+	}
+
+	batch := newMsg(
+		"Batch", map[protoreflect.Name]protoreflect.Value{
+			"start_height": protoreflect.ValueOfUint64(start),
+			"end_height":   protoreflect.ValueOfUint64(end),
+		},
+	)
+	batchBlocks := batch.Mutable(md("Batch").Fields().ByName("blocks")).List()
+	batchCommits := batch.Mutable(md("Batch").Fields().ByName("commits")).List()
+
+	for _, b := range blocks {
+		header := newMsg(
+			"Header", map[protoreflect.Name]protoreflect.Value{
+				"version": protoreflect.ValueOfMessage(
+					newMsg(
+						"Version", map[protoreflect.Name]protoreflect.Value{
+							"block": protoreflect.ValueOfUint64(11),
+							"app":   protoreflect.ValueOfUint64(1),
+						},
+					),
+				),
+				"height": protoreflect.ValueOfUint64(b.height),
+				"time": protoreflect.ValueOfUint64(
+					// nolint: gosec
+					uint64(testTime.Add(time.Duration(b.height) * time.Second).UnixNano()),
+				),
+				"last_header_hash": protoreflect.ValueOfBytes(b.lastHeaderHash),
+				"last_commit_hash": protoreflect.ValueOfBytes(hash(0x01)),
+				"data_hash":        protoreflect.ValueOfBytes(hash(0x02)),
+				"consensus_hash":   protoreflect.ValueOfBytes(hash(0x03)),
+				"app_hash":         protoreflect.ValueOfBytes(b.appHash),
+				"proposer_address": protoreflect.ValueOfBytes([]byte("proposer")),
+				"sequencer_hash":   protoreflect.ValueOfBytes(hash(0x04)),
+				"chain_id":         protoreflect.ValueOfString("rollappevm_1234-1"),
+			},
+		)
+
+		data := newMsg("Data", nil)
+		txs := data.Mutable(md("Data").Fields().ByName("txs")).List()
+		for i := 0; i < b.txs; i++ {
+			txs.Append(protoreflect.ValueOfBytes([]byte("tx" + strconv.Itoa(i))))
+		}
+		roots := data.Mutable(md("Data").Fields().ByName("intermediate_state_roots")).List()
+		roots.Append(protoreflect.ValueOfBytes(hash(0x05)))
+
+		block := newMsg(
+			"Block", map[protoreflect.Name]protoreflect.Value{
+				"header": protoreflect.ValueOfMessage(header),
+				"data":   protoreflect.ValueOfMessage(data),
+			},
+		)
+		batchBlocks.Append(protoreflect.ValueOfMessage(block))
+
+		commit := newMsg(
+			"Commit", map[protoreflect.Name]protoreflect.Value{
+				"height":      protoreflect.ValueOfUint64(b.height),
+				"header_hash": protoreflect.ValueOfBytes(b.headerHash),
+			},
+		)
+		sigs := commit.Mutable(md("Commit").Fields().ByName("signatures")).List()
+		sigs.Append(protoreflect.ValueOfBytes([]byte("signature")))
+		batchCommits.Append(protoreflect.ValueOfMessage(commit))
+	}
+
+	b, err := proto.Marshal(batch)
+	require.NoError(t, err)
+
+	return b
+}
+
+// stateInfo returns the state update matching the blocks
+func stateInfo(start uint64, blocks []testBlock) rollapputils.StateInfo {
+	var si rollapputils.StateInfo
+	si.StartHeight = strconv.FormatUint(start, 10)
+	si.NumBlocks = strconv.Itoa(len(blocks))
+	for _, b := range blocks {
+		si.BDs.BD = append(
+			si.BDs.BD, rollapputils.BlockDescriptor{
+				Height:    strconv.FormatUint(b.height, 10),
+				StateRoot: b.appHash,
+			},
+		)
+	}
+
+	return si
+}
+
+func TestDecodeBatch(t *testing.T) {
+	blocks := linkedBlocks(100, 3)
+	b, err := DecodeBatch(encodeBatch(t, 100, 102, blocks))
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(100), b.StartHeight)
+	require.Equal(t, uint64(102), b.EndHeight)
+	require.Len(t, b.Blocks, 3)
+	for i, block := range b.Blocks {
+		want := blocks[i]
+		require.Equal(t, want.height, block.Height)
+		require.Equal(t, testTime.Add(time.Duration(want.height)*time.Second), block.Time)
+		require.Equal(t, "rollappevm_1234-1", block.ChainID)
+		require.Equal(t, want.lastHeaderHash, block.LastHeaderHash)
+		require.Equal(t, hash(0x02), block.DataHash)
+		require.Equal(t, want.appHash, block.AppHash)
+		require.Equal(t, []byte("proposer"), block.ProposerAddress)
+		require.Equal(t, want.txs, block.NumTxs)
+		require.Equal(t, want.headerHash, block.HeaderHash)
+	}
+}
+
+func TestDecodeBatchInvalid(t *testing.T) {
+	valid := encodeBatch(t, 1, 2, linkedBlocks(1, 2))
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated", data: valid[:len(valid)-3]},
+		{name: "invalid tag", data: []byte{0xff, 0xff, 0xff}},
+		{name: "invalid block", data: []byte{0x1a, 0x02, 0xff, 0xff}},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				_, err := DecodeBatch(tc.data)
+				require.Error(t, err)
+			},
+		)
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end uint64
+		blocks     func() []testBlock
+		stateInfo  func(blocks []testBlock) rollapputils.StateInfo
+		mismatches []string
+		err        bool
+	}{
+		{
+			name:  "matching batch",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return linkedBlocks(10, 3) },
+		},
+		{
+			name:  "different heights",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return linkedBlocks(10, 3) },
+			stateInfo: func(blocks []testBlock) rollapputils.StateInfo {
+				si := stateInfo(10, blocks)
+				si.StartHeight = "11"
+				si.NumBlocks = "4"
+				return si
+			},
+			mismatches: []string{
+				"start height: batch 10, hub 11",
+				"end height: batch 12, hub 14",
+				"number of blocks: batch 3, hub 4",
+			},
+		},
+		{
+			name:  "gap between blocks",
+			start: 10, end: 12,
+			blocks: func() []testBlock {
+				blocks := linkedBlocks(10, 3)
+				blocks[2].height = 13
+				return blocks
+			},
+			stateInfo: func(blocks []testBlock) rollapputils.StateInfo {
+				si := stateInfo(10, blocks)
+				si.BDs.BD = si.BDs.BD[:2]
+				return si
+			},
+			mismatches: []string{"block 13 follows block 11"},
+		},
+		{
+			name:  "broken header link",
+			start: 10, end: 12,
+			blocks: func() []testBlock {
+				blocks := linkedBlocks(10, 3)
+				blocks[1].lastHeaderHash = hash(0xee)
+				return blocks
+			},
+			mismatches: []string{"block 11 doesn't link to the header of block 10"},
+		},
+		{
+			name:  "different state root",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return linkedBlocks(10, 3) },
+			stateInfo: func(blocks []testBlock) rollapputils.StateInfo {
+				si := stateInfo(10, blocks)
+				si.BDs.BD[1].StateRoot = []byte{0xab}
+				return si
+			},
+			mismatches: []string{
+				"block 11 state root: batch " +
+					"8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b8b, hub ab",
+			},
+		},
+		{
+			name:  "block missing from the batch",
+			start: 10, end: 11,
+			blocks: func() []testBlock { return linkedBlocks(10, 2) },
+			stateInfo: func([]testBlock) rollapputils.StateInfo {
+				return stateInfo(10, linkedBlocks(10, 3))
+			},
+			mismatches: []string{
+				"end height: batch 11, hub 12",
+				"number of blocks: batch 2, hub 3",
+				"block 12 is missing from the batch",
+			},
+		},
+		{
+			name:  "empty batch",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return nil },
+			stateInfo: func([]testBlock) rollapputils.StateInfo {
+				return stateInfo(10, linkedBlocks(10, 3))
+			},
+			err: true,
+		},
+		{
+			name:  "invalid state info",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return linkedBlocks(10, 3) },
+			stateInfo: func(blocks []testBlock) rollapputils.StateInfo {
+				si := stateInfo(10, blocks)
+				si.NumBlocks = "three"
+				return si
+			},
+			err: true,
+		},
+		{
+			name:  "invalid block descriptor",
+			start: 10, end: 12,
+			blocks: func() []testBlock { return linkedBlocks(10, 3) },
+			stateInfo: func(blocks []testBlock) rollapputils.StateInfo {
+				si := stateInfo(10, blocks)
+				si.BDs.BD[0].Height = "ten"
+				return si
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				blocks := tc.blocks()
+				b, err := DecodeBatch(encodeBatch(t, tc.start, tc.end, blocks))
+				require.NoError(t, err)
+
+				si := stateInfo(tc.start, blocks)
+				if tc.stateInfo != nil {
+					si = tc.stateInfo(blocks)
+				}
+
+				mismatches, err := VerifyBatch(b, si)
+				if tc.err {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.mismatches, mismatches)
+			},
+		)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -243,3 +244,36 @@ func GetNodeID(home string) (string, error) {
 
 	return out.String(), nil
 }
+
+type statusResponse struct {
+	Result struct {
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// GetLocalHeight returns the latest block height of the node serving the
+// provided RPC endpoint
+func GetLocalHeight(rpcURL string) (int64, error) {
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(rpcURL + "/status")
+	if err != nil {
+		return 0, err
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var sr statusResponse
+	err = json.Unmarshal(body, &sr)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(sr.Result.SyncInfo.LatestBlockHeight, 10, 64)
+}
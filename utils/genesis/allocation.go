@@ -0,0 +1,480 @@
+package genesis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dymensionxyz/roller/utils/config"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// AllocationPlan describes the initial token distribution of the rollapp.
+// Amounts are expressed in display units, e.g. 1.5 for 1.5 tokens, and are
+// converted to the base denom with the configured decimals
+type AllocationPlan struct {
+	TotalSupply string       `yaml:"total_supply"`
+	Decimals    *uint        `yaml:"decimals"`
+	Allocations []Allocation `yaml:"allocations"`
+}
+
+// Allocation assigns tokens either to an address or to a module account
+type Allocation struct {
+	Address     string   `yaml:"address"`
+	Module      string   `yaml:"module"`
+	Permissions []string `yaml:"permissions"`
+	Amount      string   `yaml:"amount"`
+	Vesting     *Vesting `yaml:"vesting"`
+}
+
+// Vesting locks the amount, or a part of it, until the end time. Without a
+// start time the tokens unlock at once at the end time, otherwise they
+// unlock linearly between start and end
+type Vesting struct {
+	Amount string `yaml:"amount"`
+	Start  string `yaml:"start"`
+	End    string `yaml:"end"`
+}
+
+var csvColumns = []string{
+	"address",
+	"module",
+	"amount",
+	"vesting_amount",
+	"vesting_start",
+	"vesting_end",
+}
+
+// LoadAllocationPlan reads the allocation plan from a .yaml/.yml or a .csv
+// file. CSV files contain only the allocations, one per row, with a header
+// row naming the columns
+func LoadAllocationPlan(path string) (*AllocationPlan, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var p AllocationPlan
+		err = yaml.Unmarshal(b, &p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allocation file %s: %w", path, err)
+		}
+		return &p, nil
+	case ".csv":
+		return loadAllocationCSV(path)
+	default:
+		return nil, fmt.Errorf("unsupported allocation file %s, use .yaml or .csv", path)
+	}
+}
+
+func loadAllocationCSV(path string) (*AllocationPlan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:errcheck
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the csv header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if !slices.Contains(csvColumns, h) {
+			return nil, fmt.Errorf(
+				"unknown column %q, supported columns: %s",
+				h,
+				strings.Join(csvColumns, ", "),
+			)
+		}
+		columns[h] = i
+	}
+
+	var p AllocationPlan
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		get := func(c string) string {
+			i, ok := columns[c]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		a := Allocation{
+			Address: get("address"),
+			Module:  get("module"),
+			Amount:  get("amount"),
+		}
+		if get("vesting_end") != "" || get("vesting_start") != "" || get("vesting_amount") != "" {
+			a.Vesting = &Vesting{
+				Amount: get("vesting_amount"),
+				Start:  get("vesting_start"),
+				End:    get("vesting_end"),
+			}
+		}
+
+		p.Allocations = append(p.Allocations, a)
+	}
+
+	return &p, nil
+}
+
+// genesisAllocation is a validated allocation, with amounts in the base denom
+type genesisAllocation struct {
+	address       string
+	module        string
+	permissions   []string
+	amount        cosmossdkmath.Int
+	vestingAmount cosmossdkmath.Int
+	vestingStart  int64
+	vestingEnd    int64
+}
+
+// Validate checks every allocation and that the allocations add up to the
+// total supply
+func (p *AllocationPlan) Validate(bech32Prefix string) error {
+	_, err := p.validate(bech32Prefix)
+	return err
+}
+
+// validate returns the allocations with the amounts converted to the base denom
+func (p *AllocationPlan) validate(bech32Prefix string) ([]genesisAllocation, error) {
+	if p.Decimals == nil {
+		return nil, errors.New("decimals are not set")
+	}
+	err := roller.ValidateDecimals(*p.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.TotalSupply == "" {
+		return nil, errors.New("total supply is not set")
+	}
+	totalSupply, err := toBaseAmount(p.TotalSupply, *p.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid total supply: %w", err)
+	}
+
+	if len(p.Allocations) == 0 {
+		return nil, errors.New("no allocations defined")
+	}
+
+	var errs []error
+	var allocations []genesisAllocation
+	seen := make(map[string]int)
+	sum := cosmossdkmath.ZeroInt()
+
+	for i, a := range p.Allocations {
+		ga, err := a.toGenesisAllocation(bech32Prefix, *p.Decimals)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("allocation %d: %w", i+1, err))
+			continue
+		}
+
+		if prev, ok := seen[ga.address]; ok {
+			errs = append(
+				errs,
+				fmt.Errorf("allocation %d: %s is already allocated in %d", i+1, ga.address, prev),
+			)
+			continue
+		}
+		seen[ga.address] = i + 1
+
+		sum = sum.Add(ga.amount)
+		allocations = append(allocations, *ga)
+	}
+
+	if len(errs) == 0 && !sum.Equal(totalSupply) {
+		errs = append(
+			errs,
+			fmt.Errorf(
+				"the allocations add up to %s, the total supply is %s (base denom)",
+				sum.String(),
+				totalSupply.String(),
+			),
+		)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return allocations, nil
+}
+
+func (a Allocation) toGenesisAllocation(prefix string, decimals uint) (*genesisAllocation, error) {
+	ga := &genesisAllocation{
+		module:        a.Module,
+		permissions:   a.Permissions,
+		vestingAmount: cosmossdkmath.ZeroInt(),
+	}
+
+	switch {
+	case a.Address != "" && a.Module != "":
+		return nil, errors.New("only one of address and module can be set")
+	case a.Module != "":
+		addr, err := bech32.ConvertAndEncode(prefix, crypto.AddressHash([]byte(a.Module)))
+		if err != nil {
+			return nil, err
+		}
+		ga.address = addr
+	case a.Address != "":
+		hrp, _, err := bech32.DecodeAndConvert(a.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %w", a.Address, err)
+		}
+		if hrp != prefix {
+			return nil, fmt.Errorf("invalid address %s, expected the %s prefix", a.Address, prefix)
+		}
+		ga.address = a.Address
+	default:
+		return nil, errors.New("either address or module has to be set")
+	}
+
+	amount, err := toBaseAmount(a.Amount, decimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("amount has to be positive")
+	}
+	ga.amount = amount
+
+	if a.Vesting == nil {
+		return ga, nil
+	}
+
+	if a.Module != "" {
+		return nil, errors.New("module accounts can't vest")
+	}
+
+	ga.vestingAmount = amount
+	if a.Vesting.Amount != "" {
+		ga.vestingAmount, err = toBaseAmount(a.Vesting.Amount, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vesting amount: %w", err)
+		}
+	}
+	if !ga.vestingAmount.IsPositive() || ga.vestingAmount.GT(amount) {
+		return nil, errors.New("vesting amount has to be positive and not exceed the amount")
+	}
+
+	if a.Vesting.End == "" {
+		return nil, errors.New("vesting end is not set")
+	}
+	ga.vestingEnd, err = parseTime(a.Vesting.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vesting end: %w", err)
+	}
+
+	if a.Vesting.Start != "" {
+		ga.vestingStart, err = parseTime(a.Vesting.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vesting start: %w", err)
+		}
+		if ga.vestingStart >= ga.vestingEnd {
+			return nil, errors.New("vesting start has to be before the vesting end")
+		}
+	}
+
+	return ga, nil
+}
+
+// accountJSON returns the auth genesis representation of the allocation
+func (ga genesisAllocation) accountJSON(denom string) map[string]any {
+	baseAccount := map[string]any{
+		"address":        ga.address,
+		"pub_key":        nil,
+		"account_number": "0",
+		"sequence":       "0",
+	}
+
+	if ga.module != "" {
+		permissions := ga.permissions
+		if permissions == nil {
+			permissions = []string{}
+		}
+
+		return map[string]any{
+			"@type":        "/cosmos.auth.v1beta1.ModuleAccount",
+			"base_account": baseAccount,
+			"name":         ga.module,
+			"permissions":  permissions,
+		}
+	}
+
+	if ga.vestingEnd == 0 {
+		baseAccount["@type"] = "/cosmos.auth.v1beta1.BaseAccount"
+		return baseAccount
+	}
+
+	baseVestingAccount := map[string]any{
+		"base_account":      baseAccount,
+		"original_vesting":  []Denom{{Denom: denom, Amount: ga.vestingAmount.String()}},
+		"delegated_free":    []Denom{},
+		"delegated_vesting": []Denom{},
+		"end_time":          strconv.FormatInt(ga.vestingEnd, 10),
+	}
+
+	if ga.vestingStart == 0 {
+		return map[string]any{
+			"@type":                "/cosmos.vesting.v1beta1.DelayedVestingAccount",
+			"base_vesting_account": baseVestingAccount,
+		}
+	}
+
+	return map[string]any{
+		"@type":                "/cosmos.vesting.v1beta1.ContinuousVestingAccount",
+		"base_vesting_account": baseVestingAccount,
+		"start_time":           strconv.FormatInt(ga.vestingStart, 10),
+	}
+}
+
+// getAllocationParams returns the genesis updates applying the allocations.
+// The balances and the supply are replaced, existing accounts are kept
+// unless they're redefined by an allocation
+func getAllocationParams(
+	genesisPath, denom string,
+	allocations []genesisAllocation,
+) ([]config.PathValue, error) {
+	as, err := readAppState(genesisPath)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated := make(map[string]struct{})
+	for _, a := range allocations {
+		allocated[a.address] = struct{}{}
+	}
+
+	var accounts []any
+	for _, raw := range as.Auth.Accounts {
+		if _, ok := allocated[accountAddress(raw)]; ok {
+			continue
+		}
+		accounts = append(accounts, raw)
+	}
+
+	balances := []Balance{}
+	supply := cosmossdkmath.ZeroInt()
+	for _, a := range allocations {
+		accounts = append(accounts, a.accountJSON(denom))
+		balances = append(
+			balances, Balance{
+				Address: a.address,
+				Coins:   []Denom{{Denom: denom, Amount: a.amount.String()}},
+			},
+		)
+		supply = supply.Add(a.amount)
+	}
+
+	return []config.PathValue{
+		{Path: "app_state.auth.accounts", Value: accounts},
+		{Path: "app_state.bank.balances", Value: balances},
+		{
+			Path:  "app_state.bank.supply",
+			Value: []Denom{{Denom: denom, Amount: supply.String()}},
+		},
+	}, nil
+}
+
+func readAppState(genesisPath string) (*AppState, error) {
+	b, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var g struct {
+		AppState AppState `json:"app_state"`
+	}
+	err = json.Unmarshal(b, &g)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis file %s: %w", genesisPath, err)
+	}
+
+	return &g.AppState, nil
+}
+
+// accountAddress extracts the address of base, module and vesting accounts
+func accountAddress(raw json.RawMessage) string {
+	var acc struct {
+		Address     string `json:"address"`
+		BaseAccount struct {
+			Address string `json:"address"`
+		} `json:"base_account"`
+		BaseVestingAccount struct {
+			BaseAccount struct {
+				Address string `json:"address"`
+			} `json:"base_account"`
+		} `json:"base_vesting_account"`
+	}
+	_ = json.Unmarshal(raw, &acc)
+
+	switch {
+	case acc.Address != "":
+		return acc.Address
+	case acc.BaseAccount.Address != "":
+		return acc.BaseAccount.Address
+	default:
+		return acc.BaseVestingAccount.BaseAccount.Address
+	}
+}
+
+// toBaseAmount converts a display amount to the base denom
+func toBaseAmount(v string, decimals uint) (cosmossdkmath.Int, error) {
+	d, err := cosmossdkmath.LegacyNewDecFromStr(strings.TrimSpace(v))
+	if err != nil {
+		return cosmossdkmath.Int{}, err
+	}
+
+	base := d.Mul(cosmossdkmath.LegacyNewDecFromInt(cosmossdkmath.NewIntWithDecimal(1, int(decimals))))
+	if !base.IsInteger() {
+		return cosmossdkmath.Int{}, fmt.Errorf("%s has more than %d decimals", v, decimals)
+	}
+
+	return base.TruncateInt(), nil
+}
+
+// parseTime accepts unix timestamps and RFC3339 dates
+func parseTime(v string) (int64, error) {
+	if ts, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return ts, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, fmt.Errorf("%s is neither a unix timestamp nor an RFC3339 date", v)
+	}
+
+	return t.Unix(), nil
+}
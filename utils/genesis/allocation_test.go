@@ -0,0 +1,338 @@
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPrefix   = "ethm"
+	testAddress  = "ethm1qyqszqgpqyqszqgpqyqszqgpqyqszqgpfa0uw7"
+	testAddress2 = "ethm1qgpqyqszqgpqyqszqgpqyqszqgpqyqszcefe9g"
+)
+
+func TestLoadAllocationPlan(t *testing.T) {
+	decimals := uint(18)
+
+	tests := []struct {
+		name string
+		path string
+		want *AllocationPlan
+		err  string
+	}{
+		{
+			name: "yaml",
+			path: "testdata/allocation.yaml",
+			want: &AllocationPlan{
+				TotalSupply: "1000",
+				Decimals:    &decimals,
+				Allocations: []Allocation{
+					{
+						Address: testAddress,
+						Amount:  "600.5",
+						Vesting: &Vesting{
+							Amount: "100",
+							Start:  "2025-01-01T00:00:00Z",
+							End:    "2026-01-01T00:00:00Z",
+						},
+					},
+					{
+						Module:      "treasury",
+						Permissions: []string{"burner"},
+						Amount:      "399.5",
+					},
+				},
+			},
+		},
+		{
+			name: "csv with reordered and missing columns",
+			path: "testdata/allocation.csv",
+			want: &AllocationPlan{
+				Allocations: []Allocation{
+					{
+						Address: testAddress,
+						Amount:  "600.5",
+						Vesting: &Vesting{End: "1767225600"},
+					},
+					{Address: testAddress2, Amount: "399.5"},
+					{Module: "treasury", Amount: "1"},
+				},
+			},
+		},
+		{
+			name: "csv with an unknown column",
+			path: "testdata/allocation_unknown_column.csv",
+			err:  `unknown column "lockup"`,
+		},
+		{
+			name: "unsupported extension",
+			path: "testdata/allocation.json",
+			err:  "unsupported allocation file",
+		},
+		{
+			name: "missing file",
+			path: "testdata/missing.yaml",
+			err:  "no such file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				p, err := LoadAllocationPlan(tc.path)
+				if tc.err != "" {
+					require.ErrorContains(t, err, tc.err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.want, p)
+			},
+		)
+	}
+}
+
+func TestLoadAllocationPlanInvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allocation.yml")
+	require.NoError(t, os.WriteFile(path, []byte("allocations: {address"), 0o644))
+
+	_, err := LoadAllocationPlan(path)
+	require.ErrorContains(t, err, "invalid allocation file")
+}
+
+func TestValidateAllocationPlan(t *testing.T) {
+	decimals := uint(6)
+	tooManyDecimals := uint(19)
+
+	tests := []struct {
+		name        string
+		plan        AllocationPlan
+		allocations []genesisAllocation
+		err         string
+	}{
+		{
+			name: "address and module allocations",
+			plan: AllocationPlan{
+				TotalSupply: "10",
+				Decimals:    &decimals,
+				Allocations: []Allocation{
+					{Address: testAddress, Amount: "2.5"},
+					{Module: "treasury", Permissions: []string{"burner"}, Amount: "7.5"},
+				},
+			},
+		},
+		{
+			name: "delayed and continuous vesting",
+			plan: AllocationPlan{
+				TotalSupply: "10",
+				Decimals:    &decimals,
+				Allocations: []Allocation{
+					{
+						Address: testAddress,
+						Amount:  "5",
+						Vesting: &Vesting{End: "2026-01-01T00:00:00Z"},
+					},
+					{
+						Address: testAddress2,
+						Amount:  "5",
+						Vesting: &Vesting{Amount: "1", Start: "1735689600", End: "1767225600"},
+					},
+				},
+			},
+		},
+		{
+			name: "decimals are not set",
+			plan: AllocationPlan{TotalSupply: "10"},
+			err:  "decimals are not set",
+		},
+		{
+			name: "too many decimals",
+			plan: AllocationPlan{TotalSupply: "10", Decimals: &tooManyDecimals},
+			err:  "invalid decimals",
+		},
+		{
+			name: "total supply is not set",
+			plan: AllocationPlan{Decimals: &decimals},
+			err:  "total supply is not set",
+		},
+		{
+			name: "total supply with more decimals than the token",
+			plan: AllocationPlan{TotalSupply: "0.0000001", Decimals: &decimals},
+			err:  "invalid total supply: 0.0000001 has more than 6 decimals",
+		},
+		{
+			name: "no allocations",
+			plan: AllocationPlan{TotalSupply: "10", Decimals: &decimals},
+			err:  "no allocations defined",
+		},
+		{
+			name: "allocations don't add up to the total supply",
+			plan: AllocationPlan{
+				TotalSupply: "10",
+				Decimals:    &decimals,
+				Allocations: []Allocation{{Address: testAddress, Amount: "9"}},
+			},
+			err: "the allocations add up to 9000000, the total supply is 10000000",
+		},
+		{
+			name: "duplicate address",
+			plan: AllocationPlan{
+				TotalSupply: "10",
+				Decimals:    &decimals,
+				Allocations: []Allocation{
+					{Address: testAddress, Amount: "5"},
+					{Address: testAddress, Amount: "5"},
+				},
+			},
+			err: "allocation 2: " + testAddress + " is already allocated in 1",
+		},
+		{
+			name: "address and module",
+			plan: plan(&decimals, Allocation{Address: testAddress, Module: "treasury", Amount: "1"}),
+			err:  "allocation 1: only one of address and module can be set",
+		},
+		{
+			name: "neither address nor module",
+			plan: plan(&decimals, Allocation{Amount: "1"}),
+			err:  "allocation 1: either address or module has to be set",
+		},
+		{
+			name: "invalid address",
+			plan: plan(&decimals, Allocation{Address: "ethm1invalid", Amount: "1"}),
+			err:  "allocation 1: invalid address ethm1invalid",
+		},
+		{
+			name: "address with another prefix",
+			plan: plan(
+				&decimals,
+				Allocation{Address: "dym1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqwdcgj", Amount: "1"},
+			),
+			err: "expected the ethm prefix",
+		},
+		{
+			name: "invalid amount",
+			plan: AllocationPlan{
+				TotalSupply: "1",
+				Decimals:    &decimals,
+				Allocations: []Allocation{{Address: testAddress, Amount: "one"}},
+			},
+			err: "allocation 1: invalid amount",
+		},
+		{
+			name: "zero amount",
+			plan: plan(&decimals, Allocation{Address: testAddress, Amount: "0"}),
+			err:  "allocation 1: amount has to be positive",
+		},
+		{
+			name: "vesting module account",
+			plan: plan(
+				&decimals,
+				Allocation{Module: "treasury", Amount: "1", Vesting: &Vesting{End: "1767225600"}},
+			),
+			err: "allocation 1: module accounts can't vest",
+		},
+		{
+			name: "vesting amount exceeds the amount",
+			plan: plan(
+				&decimals,
+				Allocation{
+					Address: testAddress,
+					Amount:  "1",
+					Vesting: &Vesting{Amount: "2", End: "1767225600"},
+				},
+			),
+			err: "vesting amount has to be positive and not exceed the amount",
+		},
+		{
+			name: "vesting end is not set",
+			plan: plan(&decimals, Allocation{Address: testAddress, Amount: "1", Vesting: &Vesting{}}),
+			err:  "vesting end is not set",
+		},
+		{
+			name: "invalid vesting end",
+			plan: plan(
+				&decimals,
+				Allocation{Address: testAddress, Amount: "1", Vesting: &Vesting{End: "next year"}},
+			),
+			err: "invalid vesting end: next year is neither a unix timestamp nor an RFC3339 date",
+		},
+		{
+			name: "vesting starts after the end",
+			plan: plan(
+				&decimals,
+				Allocation{
+					Address: testAddress,
+					Amount:  "1",
+					Vesting: &Vesting{Start: "1767225600", End: "1735689600"},
+				},
+			),
+			err: "vesting start has to be before the vesting end",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				err := tc.plan.Validate(testPrefix)
+				if tc.err != "" {
+					require.ErrorContains(t, err, tc.err)
+					return
+				}
+				require.NoError(t, err)
+			},
+		)
+	}
+}
+
+// plan returns a plan whose total supply matches the single allocation
+func plan(decimals *uint, a Allocation) AllocationPlan {
+	return AllocationPlan{
+		TotalSupply: a.Amount,
+		Decimals:    decimals,
+		Allocations: []Allocation{a},
+	}
+}
+
+func TestValidateAllocationPlanCollectsErrors(t *testing.T) {
+	decimals := uint(6)
+	p := AllocationPlan{
+		TotalSupply: "10",
+		Decimals:    &decimals,
+		Allocations: []Allocation{
+			{Amount: "1"},
+			{Address: testAddress, Amount: "9"},
+			{Address: testAddress2, Amount: "-1"},
+		},
+	}
+
+	err := p.Validate(testPrefix)
+	require.ErrorContains(t, err, "allocation 1: either address or module has to be set")
+	require.ErrorContains(t, err, "allocation 3: amount has to be positive")
+	// the sum is only checked when every allocation is valid
+	require.NotContains(t, err.Error(), "add up to")
+}
+
+func TestValidateConvertsAmounts(t *testing.T) {
+	p, err := LoadAllocationPlan("testdata/allocation.yaml")
+	require.NoError(t, err)
+
+	allocations, err := p.validate(testPrefix)
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+
+	vesting := allocations[0]
+	require.Equal(t, testAddress, vesting.address)
+	require.Equal(t, "600500000000000000000", vesting.amount.String())
+	require.Equal(t, "100000000000000000000", vesting.vestingAmount.String())
+	require.Equal(t, int64(1735689600), vesting.vestingStart)
+	require.Equal(t, int64(1767225600), vesting.vestingEnd)
+
+	module := allocations[1]
+	require.Equal(t, "treasury", module.module)
+	require.Equal(t, []string{"burner"}, module.permissions)
+	require.Equal(t, "399500000000000000000", module.amount.String())
+	require.True(t, module.vestingAmount.IsZero())
+	require.Contains(t, module.address, testPrefix+"1")
+}
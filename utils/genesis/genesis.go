@@ -26,12 +26,24 @@ import (
 )
 
 type AppState struct {
+	Auth          Auth          `json:"auth"`
 	Bank          Bank          `json:"bank"`
 	RollappParams RollappParams `json:"rollappparams"`
 }
 
+type Auth struct {
+	Accounts []json.RawMessage `json:"accounts"`
+}
+
 type Bank struct {
-	Supply []Denom `json:"supply"`
+	Balances      []Balance           `json:"balances"`
+	Supply        []Denom             `json:"supply"`
+	DenomMetadata []BankDenomMetadata `json:"denom_metadata"`
+}
+
+type Balance struct {
+	Address string  `json:"address"`
+	Coins   []Denom `json:"coins"`
 }
 
 type RollappParams struct {
@@ -42,7 +54,8 @@ type RollappParams struct {
 }
 
 type Denom struct {
-	Denom string `json:"denom"`
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
 }
 
 func DownloadGenesis(home, genesisUrl string) error {
@@ -215,6 +228,7 @@ func InitializeRollappGenesis(initConfig roller.RollappConfig) error {
 	err := UpdateGenesisParams(
 		initConfig.Home,
 		&initConfig,
+		nil,
 	)
 	if err != nil {
 		return err
@@ -223,20 +237,38 @@ func InitializeRollappGenesis(initConfig roller.RollappConfig) error {
 	return nil
 }
 
-func UpdateGenesisParams(home string, raCfg *roller.RollappConfig) error {
-	params := getDefaultGenesisParams(raCfg)
-	addGenAccountCmd := GetAddGenesisAccountCmd(
-		consts.KeysIds.RollappSequencer,
-		consts.DefaultTokenSupply,
-		raCfg,
-	)
-
+// UpdateGenesisParams applies the default rollapp params to the genesis file.
+// Without an allocation plan, the default token supply is minted to the
+// sequencer key, otherwise the balances are replaced by the plan allocations
+func UpdateGenesisParams(home string, raCfg *roller.RollappConfig, plan *AllocationPlan) error {
 	genesisFilePath := filepath.Join(home, consts.ConfigDirName.Rollapp, "config", "genesis.json")
-	_, err := bash.ExecCommandWithStdout(addGenAccountCmd)
-	if err != nil {
-		return err
+
+	var allocationParams []config.PathValue
+	if plan == nil {
+		addGenAccountCmd := GetAddGenesisAccountCmd(
+			consts.KeysIds.RollappSequencer,
+			consts.DefaultTokenSupply,
+			raCfg,
+		)
+
+		_, err := bash.ExecCommandWithStdout(addGenAccountCmd)
+		if err != nil {
+			return err
+		}
+	} else {
+		allocations, err := plan.validate(raCfg.Bech32Prefix)
+		if err != nil {
+			return err
+		}
+		raCfg.Decimals = *plan.Decimals
+
+		allocationParams, err = getAllocationParams(genesisFilePath, raCfg.BaseDenom, allocations)
+		if err != nil {
+			return err
+		}
 	}
 
+	params := append(getDefaultGenesisParams(raCfg), allocationParams...)
 	return jsonconfig.UpdateJSONParams(genesisFilePath, params)
 }
 
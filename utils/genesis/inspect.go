@@ -0,0 +1,133 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	comettypes "github.com/cometbft/cometbft/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/jsondiff"
+)
+
+// Summary is an overview of the contents of a genesis file
+type Summary struct {
+	Path          string
+	Checksum      string
+	ChainID       string
+	GenesisTime   time.Time
+	InitialHeight int64
+	Accounts      int
+	Balances      int
+	Supply        []Denom
+	DenomMetadata []BankDenomMetadata
+	Version       string
+	DA            string
+}
+
+// FieldDiff is a single difference between two genesis files
+type FieldDiff = jsondiff.FieldDiff
+
+func Summarize(path string) (*Summary, error) {
+	doc, err := comettypes.GenesisDocFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var as AppState
+	err = json.Unmarshal(doc.AppState, &as)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app state: %w", err)
+	}
+
+	checksum, err := calculateSHA256(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Summary{
+		Path:          path,
+		Checksum:      checksum,
+		ChainID:       doc.ChainID,
+		GenesisTime:   doc.GenesisTime,
+		InitialHeight: doc.InitialHeight,
+		Accounts:      len(as.Auth.Accounts),
+		Balances:      len(as.Bank.Balances),
+		Supply:        as.Bank.Supply,
+		DenomMetadata: as.Bank.DenomMetadata,
+		Version:       as.RollappParams.Params.Version,
+		DA:            as.RollappParams.Params.Da,
+	}, nil
+}
+
+// GetRollappGenesisChecksum returns the genesis checksum registered for the
+// rollapp on the hub
+func GetRollappGenesisChecksum(raID string, hd consts.HubData) (string, error) {
+	checksum, err := getRollappGenesisHash(raID, hd)
+	if err != nil {
+		return "", err
+	}
+
+	if checksum == "" {
+		return "", fmt.Errorf("no genesis checksum is registered for %s", raID)
+	}
+
+	return checksum, nil
+}
+
+// VerifyChecksum compares the SHA-256 checksum of the genesis file with the
+// expected one
+func VerifyChecksum(path, expected string) error {
+	checksum, err := calculateSHA256(path)
+	if err != nil {
+		return err
+	}
+
+	if checksum != expected {
+		return fmt.Errorf(
+			"the hash of the genesis file (%s) does not match the expected one (%s)",
+			checksum,
+			expected,
+		)
+	}
+
+	return nil
+}
+
+// Diff returns the semantic differences between two genesis files, key
+// ordering and formatting are ignored. The diff is sorted by field path
+func Diff(leftPath, rightPath string) ([]FieldDiff, error) {
+	left, err := decodeFile(leftPath)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := decodeFile(rightPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsondiff.Compare(left, right, true), nil
+}
+
+func decodeFile(path string) (any, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as written, genesis amounts don't fit into a float64
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var v any
+	err = d.Decode(&v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis file %s: %w", path, err)
+	}
+
+	return v, nil
+}
@@ -3,27 +3,63 @@ package healthagent
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pterm/pterm"
 
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/services/load"
 	"github.com/dymensionxyz/roller/cmd/services/restart"
-	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/cmd/services/stop"
+	"github.com/dymensionxyz/roller/utils/dacosts"
 	"github.com/dymensionxyz/roller/utils/dymint"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/rewards"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/standby"
+	"github.com/dymensionxyz/roller/utils/statenodes"
 )
 
+const (
+	bondPolicyCheckInterval = 10 * time.Minute
+	rewardsSweepInterval    = 1 * time.Hour
+	daCostsSampleInterval   = 1 * time.Minute
+	leaseRetryInterval      = 10 * time.Second
+)
+
+// the light client runs as this service, it's reloaded when the state node
+// changes
+var lightClientServices = []string{"da-light-client"}
+
 func Start(home string, l *log.Logger) {
+	// the bond top ups and the reward sweeps wait for their transactions,
+	// the lease is renewed separately so it doesn't expire in the meantime
+	go renewLease(home, l)
+
+	var lastBondCheck, lastRewardsSweep, lastDACostsSample, lastStateNodeRefresh time.Time
 	for {
+		if time.Since(lastBondCheck) >= bondPolicyCheckInterval {
+			lastBondCheck = time.Now()
+			checkBondPolicy(home, l)
+		}
+
+		if time.Since(lastRewardsSweep) >= rewardsSweepInterval {
+			lastRewardsSweep = time.Now()
+			sweepRewards(home, l)
+		}
+
+		if time.Since(lastDACostsSample) >= daCostsSampleInterval {
+			lastDACostsSample = time.Now()
+			recordDACosts(home, l)
+		}
+
 		var healthy bool
 		localEndpoint := "localhost"
 		defaultRaMetricPort := "2112"
@@ -41,53 +77,255 @@ func Start(home string, l *log.Logger) {
 			healthy = false
 		}
 
-		// TODO: improve the node swapping, add health checks before swapping etc.
 		if !healthy {
-			rollerData, err := roller.LoadConfig(home)
-			errorhandling.PrettifyErrorIfExists(err)
-			rollerConfigPath := roller.GetConfigPath(home)
-
-			i := slices.Index(rollerData.DA.StateNodes, rollerData.DA.CurrentStateNode)
-			var newStateNode string
-			var nodeIndex int
-			if i >= 0 && i+1 < len(rollerData.DA.StateNodes) {
-				nodeIndex = i + 1
-			} else {
-				nodeIndex = 0
-			}
+			swapStateNode(home, l)
+		} else if time.Since(lastStateNodeRefresh) >= statenodes.HealthyTTL {
+			lastStateNodeRefresh = time.Now()
+			refreshStateNode(home, l)
+		}
 
-			pterm.Warning.Printf(
-				"detected problems with DA, hotswapping node to %s\n",
-				rollerData.DA.StateNodes[nodeIndex],
-			)
-			nsn := rollerData.DA.StateNodes[nodeIndex]
-			newStateNode = nsn
-			err = tomlconfig.UpdateFieldInFile(
-				rollerConfigPath,
-				"DA.current_state_node",
-				newStateNode,
-			)
-			if err != nil {
-				pterm.Error.Println("failed to update state node: ", err)
-			}
+		healthy = true
+		time.Sleep(15 * time.Second)
+	}
+}
 
-			servicesToRestart := []string{
-				"da-light-client",
-			}
+// swapStateNode moves the light client to the best state node other than the
+// current one after problems with the DA were detected
+func swapStateNode(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return
+	}
 
-			err = load.LoadServices(servicesToRestart, rollerData)
-			if err != nil {
-				pterm.Error.Println("failed to update services")
-			}
+	if rollerData.DA.Backend != consts.Celestia {
+		return
+	}
 
-			err = restart.RestartSystemdServices(servicesToRestart, home)
-			if err != nil {
-				pterm.Error.Println("failed to restart services")
-			}
+	p, err := statenodes.Load(home)
+	if err != nil {
+		l.Println("failed to load the state node pool: ", err)
+		return
+	}
+
+	probes, err := p.Probe(rollerData, true)
+	if err != nil {
+		l.Println("failed to probe the state nodes: ", err)
+		return
+	}
+
+	err = p.Save(home)
+	if err != nil {
+		l.Println("failed to save the state node pool: ", err)
+	}
+
+	best := statenodes.Best(probes, rollerData.DA.CurrentStateNode)
+	if best == nil {
+		l.Println("detected problems with DA, no other state node is usable")
+		return
+	}
+
+	pterm.Warning.Printf("detected problems with DA, hotswapping node to %s\n", best.Node)
+	err = statenodes.SetCurrent(home, best.Node)
+	if err != nil {
+		pterm.Error.Println("failed to update state node: ", err)
+		return
+	}
+
+	reloadLightClient(home)
+}
+
+// refreshStateNode keeps the light client on the best scoring state node
+func refreshStateNode(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return
+	}
+
+	if rollerData.DA.Backend != consts.Celestia {
+		return
+	}
+
+	chosen, changed, err := statenodes.Select(rollerData, false)
+	if err != nil {
+		l.Println("failed to select a state node: ", err)
+		return
+	}
+
+	if changed {
+		l.Printf("switching the state node to %s (score %d)\n", chosen.Node, chosen.Score)
+		reloadLightClient(home)
+	}
+}
+
+func reloadLightClient(home string) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		pterm.Error.Println("failed to load roller config: ", err)
+		return
+	}
+
+	err = load.LoadServices(lightClientServices, rollerData)
+	if err != nil {
+		pterm.Error.Println("failed to update services")
+	}
+
+	err = restart.RestartSystemdServices(lightClientServices, home)
+	if err != nil {
+		pterm.Error.Println("failed to restart services")
+	}
+}
+
+// checkBondPolicy tops up the sequencer bond when it falls below the amount
+// required by the bond policy, e.g. after the hub minimum bond was raised
+func checkBondPolicy(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return
+	}
+
+	if rollerData.NodeType != consts.NodeType.Sequencer || !rollerData.BondPolicy.Enabled {
+		return
+	}
+
+	status, err := sequencer.EnforceBondPolicy(rollerData)
+	if err != nil {
+		l.Println("failed to enforce bond policy: ", err)
+		return
+	}
+
+	if status.Deficit.IsPositive() {
+		l.Printf(
+			"increased sequencer bond by %s%s to %s%s\n",
+			status.Deficit.String(),
+			consts.Denoms.Hub,
+			status.RequiredBond.String(),
+			consts.Denoms.Hub,
+		)
+	}
+}
+
+// renewLease keeps the sequencer lease of this node alive so standby nodes
+// sharing the lease file don't promote themselves while it is running. The
+// lease is renewed every third of its ttl, and only when the standby setup
+// fences promotions with a shared lease or a fence command. The rollapp is
+// stopped once the lease is held by another node or couldn't be renewed for
+// longer than its ttl, as a standby may have been promoted
+func renewLease(home string, l *log.Logger) {
+	lastRenewal := time.Now()
+	interval := leaseRenewalInterval(home, l, leaseRetryInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if i := leaseRenewalInterval(home, l, interval); i != interval {
+			interval = i
+			ticker.Reset(interval)
 		}
 
-		healthy = true
-		time.Sleep(15 * time.Second)
+		lastRenewal = tryRenewLease(home, l, lastRenewal)
+	}
+}
+
+// leaseRenewalInterval returns a third of the configured lease ttl, or the
+// current interval when the settings can't be loaded
+func leaseRenewalInterval(home string, l *log.Logger, current time.Duration) time.Duration {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return current
+	}
+
+	settings, err := standby.LoadSettings(rollerData)
+	if err != nil {
+		l.Println("failed to load standby settings: ", err)
+		return current
+	}
+
+	return settings.LeaseTTL / 3
+}
+
+// tryRenewLease renews the lease once and returns the time of the last
+// successful renewal
+func tryRenewLease(home string, l *log.Logger, lastRenewal time.Time) time.Time {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return lastRenewal
+	}
+
+	if rollerData.NodeType != consts.NodeType.Sequencer {
+		return time.Now()
+	}
+
+	settings, err := standby.LoadSettings(rollerData)
+	if err != nil {
+		l.Println("failed to load standby settings: ", err)
+		return lastRenewal
+	}
+
+	// without a standby fencing promotions there is nobody to hand the lease to
+	if !settings.CanFence() {
+		return time.Now()
+	}
+
+	address, err := sequencer.GetHubSequencerAddress(rollerData)
+	if err != nil {
+		l.Println("failed to retrieve the hub sequencer address: ", err)
+		return lastRenewal
+	}
+
+	_, err = standby.AcquireLease(settings.LeaseFile, strings.TrimSpace(address), settings.LeaseTTL)
+	if err == nil {
+		return time.Now()
+	}
+
+	l.Println("failed to renew the sequencer lease: ", err)
+	if !errors.Is(err, standby.ErrLeaseHeld) && time.Since(lastRenewal) <= settings.LeaseTTL {
+		return lastRenewal
+	}
+
+	l.Println("the sequencer lease was lost, stopping the rollapp to prevent double signing")
+	err = stop.StopSystemdServices([]string{"rollapp"})
+	if err != nil {
+		l.Println("failed to stop the rollapp: ", err)
+	}
+
+	return lastRenewal
+}
+
+func sweepRewards(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return
+	}
+
+	if rollerData.NodeType != consts.NodeType.Sequencer {
+		return
+	}
+
+	rewards.Sweep(rollerData, l)
+}
+
+// recordDACosts samples the DA balance so `roller da costs` can derive the
+// spend per batch
+func recordDACosts(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config: ", err)
+		return
+	}
+
+	if rollerData.NodeType != consts.NodeType.Sequencer {
+		return
+	}
+
+	_, err = dacosts.Record(rollerData)
+	if err != nil {
+		l.Println("failed to record the DA balance: ", err)
 	}
 }
 
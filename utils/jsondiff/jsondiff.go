@@ -0,0 +1,92 @@
+package jsondiff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// FieldDiff is a single difference between two JSON documents, fields are
+// flattened into dot separated paths, e.g. `app_state.bank.supply.0.amount`
+type FieldDiff struct {
+	Field string
+	Left  string
+	Right string
+}
+
+// Flatten maps every leaf of a decoded JSON value to its dot separated path.
+// In strict mode null values and empty objects and arrays are kept, otherwise
+// they are left out
+func Flatten(v any, strict bool) map[string]string {
+	out := make(map[string]string)
+	flatten("", v, strict, out)
+
+	return out
+}
+
+func flatten(prefix string, v any, strict bool, out map[string]string) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		if strict && len(t) == 0 {
+			out[prefix] = "{}"
+		}
+		for k, val := range t {
+			flatten(join(k), val, strict, out)
+		}
+	case []any:
+		if strict && len(t) == 0 {
+			out[prefix] = "[]"
+		}
+		for i, val := range t {
+			flatten(join(strconv.Itoa(i)), val, strict, out)
+		}
+	case nil:
+		if strict {
+			out[prefix] = "null"
+		}
+	default:
+		out[prefix] = fmt.Sprint(t)
+	}
+}
+
+// Compare returns the fields that differ between two decoded JSON values,
+// sorted by field path. In strict mode a missing field differs from an empty
+// string, see Flatten for the handling of empty values
+func Compare(left, right any, strict bool) []FieldDiff {
+	lf := Flatten(left, strict)
+	rf := Flatten(right, strict)
+
+	fields := make(map[string]struct{})
+	for k := range lf {
+		fields[k] = struct{}{}
+	}
+	for k := range rf {
+		fields[k] = struct{}{}
+	}
+
+	var diffs []FieldDiff
+	for f := range fields {
+		l, lok := lf[f]
+		r, rok := rf[f]
+		if l == r && (lok == rok || !strict) {
+			continue
+		}
+
+		diffs = append(diffs, FieldDiff{Field: f, Left: l, Right: r})
+	}
+
+	sort.Slice(
+		diffs, func(i, j int) bool {
+			return diffs[i].Field < diffs[j].Field
+		},
+	)
+
+	return diffs
+}
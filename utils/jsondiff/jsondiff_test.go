@@ -0,0 +1,88 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+
+	return v
+}
+
+func TestFlatten(t *testing.T) {
+	v := decode(t, `{"a":{"b":[1,"x"],"c":{}},"d":null,"e":[]}`)
+
+	require.Equal(
+		t, map[string]string{
+			"a.b.0": "1",
+			"a.b.1": "x",
+			"a.c":   "{}",
+			"d":     "null",
+			"e":     "[]",
+		}, Flatten(v, true),
+	)
+	require.Equal(
+		t, map[string]string{
+			"a.b.0": "1",
+			"a.b.1": "x",
+		}, Flatten(v, false),
+	)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		left   string
+		right  string
+		strict bool
+		want   []FieldDiff
+	}{
+		{
+			name:  "key order is ignored",
+			left:  `{"a":"1","b":"2"}`,
+			right: `{"b":"2","a":"1"}`,
+		},
+		{
+			name:  "changed, added and removed fields are sorted",
+			left:  `{"b":"2","c":["x","y"]}`,
+			right: `{"a":"1","b":"3","c":["x"]}`,
+			want: []FieldDiff{
+				{Field: "a", Right: "1"},
+				{Field: "b", Left: "2", Right: "3"},
+				{Field: "c.1", Left: "y"},
+			},
+		},
+		{
+			name:  "null and empty values are the same when not strict",
+			left:  `{"a":null,"b":[],"c":""}`,
+			right: `{"a":[],"b":null}`,
+		},
+		{
+			name:   "null and empty values differ when strict",
+			left:   `{"a":null,"b":[],"c":""}`,
+			right:  `{"a":[],"b":null}`,
+			strict: true,
+			want: []FieldDiff{
+				{Field: "a", Left: "null", Right: "[]"},
+				{Field: "b", Left: "[]", Right: "null"},
+				{Field: "c"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got := Compare(decode(t, tc.left), decode(t, tc.right), tc.strict)
+				require.Equal(t, tc.want, got)
+			},
+		)
+	}
+}
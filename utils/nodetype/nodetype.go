@@ -0,0 +1,76 @@
+package nodetype
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/daauth"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// Switch reconfigures the local node to run as the provided node type, it
+// updates roller.toml and the dymint DA configuration but doesn't restart
+// any services
+func Switch(rollerData roller.RollappConfig, nt string) error {
+	var p2pAdvertising string
+	switch nt {
+	case consts.NodeType.Sequencer:
+		p2pAdvertising = "false"
+	case consts.NodeType.FullNode:
+		p2pAdvertising = "true"
+	default:
+		return fmt.Errorf("unsupported node type: %s", nt)
+	}
+
+	err := tomlconfig.UpdateFieldInFile(
+		roller.GetConfigPath(rollerData.Home),
+		"node_type",
+		nt,
+	)
+	if err != nil {
+		return err
+	}
+	rollerData.NodeType = nt
+
+	dymintConfigPath := sequencer.GetDymintFilePath(rollerData.Home)
+	err = tomlconfig.UpdateFieldInFile(
+		dymintConfigPath,
+		"p2p_advertising_enabled",
+		p2pAdvertising,
+	)
+	if err != nil {
+		return err
+	}
+
+	// sequencers require an admin DA auth token to submit batches, full nodes
+	// only need read access. Only the token is replaced so the rest of the
+	// existing DA configuration, e.g. the namespace, stays untouched
+	damanager, err := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home)
+	if err != nil {
+		return err
+	}
+	generated, err := damanager.DataLayer.GetSequencerDAConfig(nt)
+	if err != nil {
+		return err
+	}
+	if generated == "" {
+		return nil
+	}
+
+	var generatedCfg map[string]any
+	err = json.Unmarshal([]byte(generated), &generatedCfg)
+	if err != nil {
+		return fmt.Errorf("failed to parse generated da_config: %w", err)
+	}
+
+	token, ok := generatedCfg["auth_token"].(string)
+	if !ok {
+		return nil
+	}
+
+	return daauth.SetDAConfigToken(rollerData.Home, token)
+}
@@ -0,0 +1,442 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const (
+	ChainHub     = "hub"
+	ChainRollapp = "rollapp"
+
+	// hubFeeBudgetTxs is the number of hub transactions whose fees are kept in
+	// the hub sequencer account when sweeping it
+	hubFeeBudgetTxs = 10
+)
+
+var gasEstimateRegex = regexp.MustCompile(`gas estimate:\s*(\d+)`)
+
+// gasAdjustment is applied to the simulated gas, expressed as a ratio to
+// avoid floating point fee calculations
+var gasAdjustment = [2]int64{13, 10}
+
+// Account is a keyring managed account that receives sequencer rewards
+type Account struct {
+	Chain    string
+	Key      keys.KeyConfig
+	Home     string
+	Address  string
+	Denom    string
+	GasPrice cosmossdkmath.Int
+	RPC      string
+	ChainID  string
+}
+
+// Withdrawal is a simulated transfer from a rewards account
+type Withdrawal struct {
+	Account     *Account
+	Destination string
+	Amount      cosmossdkmath.Int
+	Gas         cosmossdkmath.Int
+	Fee         cosmossdkmath.Int
+	Balance     cosmossdkmath.Int
+}
+
+func GetHubAccount(cfg roller.RollappConfig) (*Account, error) {
+	kc := keys.KeyConfig{
+		Dir:         consts.ConfigDirName.HubKeys,
+		ID:          consts.KeysIds.HubSequencer,
+		ChainBinary: consts.Executables.Dymension,
+		Type:        consts.SDK_ROLLAPP,
+	}
+
+	address, err := keys.GetAddressBinary(kc, cfg.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	gp, ok := cosmossdkmath.NewIntFromString(cfg.HubData.GAS_PRICE)
+	if !ok {
+		gp = cosmossdkmath.ZeroInt()
+	}
+
+	return &Account{
+		Chain:    ChainHub,
+		Key:      kc,
+		Home:     cfg.Home,
+		Address:  strings.TrimSpace(address),
+		Denom:    consts.Denoms.Hub,
+		GasPrice: gp,
+		RPC:      cfg.HubData.RPC_URL,
+		ChainID:  cfg.HubData.ID,
+	}, nil
+}
+
+// GetRollappAccount returns the rewards account on the rollapp, withdrawals
+// are only possible when the rewards key was created by roller
+func GetRollappAccount(cfg roller.RollappConfig) (*Account, error) {
+	kc := keys.KeyConfig{
+		Dir:         consts.ConfigDirName.RollappSequencerKeys,
+		ID:          consts.KeysIds.RollappSequencerReward,
+		ChainBinary: consts.Executables.RollappEVM,
+		Type:        consts.EVM_ROLLAPP,
+	}
+
+	address, err := keys.GetAddressBinary(kc, cfg.Home)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s key not found in the keyring, rewards sent to external addresses can't be managed by roller: %w",
+			kc.ID,
+			err,
+		)
+	}
+
+	return &Account{
+		Chain:    ChainRollapp,
+		Key:      kc,
+		Home:     cfg.Home,
+		Address:  strings.TrimSpace(address),
+		Denom:    RollappBaseDenom(cfg),
+		GasPrice: rollappGasPrice(cfg),
+		RPC:      consts.DefaultRollappRPC,
+		ChainID:  cfg.RollappID,
+	}, nil
+}
+
+// rollappGasPrice returns the minimum gas price of the rollapp, it's either a
+// plain amount or a coin in the rollapp base denom
+func rollappGasPrice(cfg roller.RollappConfig) cosmossdkmath.Int {
+	if gp, ok := cosmossdkmath.NewIntFromString(cfg.MinGasPrices); ok {
+		return gp
+	}
+
+	prices, err := cosmossdktypes.ParseDecCoins(cfg.MinGasPrices)
+	if err != nil {
+		return cosmossdkmath.ZeroInt()
+	}
+
+	return prices.AmountOf(RollappBaseDenom(cfg)).Ceil().TruncateInt()
+}
+
+func GetAccount(cfg roller.RollappConfig, chain string) (*Account, error) {
+	switch chain {
+	case ChainHub:
+		return GetHubAccount(cfg)
+	case ChainRollapp:
+		return GetRollappAccount(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported chain %s, use %s or %s", chain, ChainRollapp, ChainHub)
+	}
+}
+
+func RollappBaseDenom(cfg roller.RollappConfig) string {
+	if cfg.BaseDenom != "" {
+		return cfg.BaseDenom
+	}
+
+	return "a" + cfg.Denom
+}
+
+func (a *Account) binary() string {
+	return a.Key.ChainBinary
+}
+
+func (a *Account) Balance() (cosmossdkmath.Int, error) {
+	return QueryBalance(a.binary(), a.RPC, a.Denom, a.Address)
+}
+
+func QueryBalance(binary, rpc, denom, address string) (cosmossdkmath.Int, error) {
+	b, err := keys.QueryBalance(
+		keys.ChainQueryConfig{
+			Binary: binary,
+			Denom:  denom,
+			RPC:    rpc,
+		}, address,
+	)
+	if err != nil {
+		return cosmossdkmath.Int{}, err
+	}
+
+	return cosmossdkmath.NewIntFromBigInt(b.Amount), nil
+}
+
+// NewWithdrawal simulates a transfer from the account to the destination.
+// When amount is nil, everything above the reserve is withdrawn
+func NewWithdrawal(
+	a *Account,
+	destination string,
+	amount *cosmossdkmath.Int,
+	reserve cosmossdkmath.Int,
+) (*Withdrawal, error) {
+	if destination == "" {
+		return nil, errors.New("destination address cannot be empty")
+	}
+
+	balance, err := a.Balance()
+	if err != nil {
+		return nil, err
+	}
+
+	// gas usage of a bank send doesn't depend on the amount, so a minimal
+	// amount is used for the simulation to support withdrawing everything
+	args := append(
+		a.sendArgs(a.Address, destination, "1"+a.Denom),
+		"--dry-run",
+	)
+	out, err := bash.ExecCommandWithStdErr(exec.Command(a.binary(), args...))
+	if err != nil {
+		return nil, err
+	}
+
+	m := gasEstimateRegex.FindStringSubmatch(out.String())
+	if len(m) != 2 {
+		return nil, errors.New("gas estimate not found in the simulation output")
+	}
+	simulated, _ := cosmossdkmath.NewIntFromString(m[1])
+
+	gas := simulated.MulRaw(gasAdjustment[0]).QuoRaw(gasAdjustment[1])
+	fee := gas.Mul(a.GasPrice)
+	if a.Chain == ChainHub && fee.IsZero() {
+		fee = cosmossdkmath.NewInt(consts.DefaultTxFee)
+	}
+
+	w := &Withdrawal{
+		Account:     a,
+		Destination: destination,
+		Gas:         gas,
+		Fee:         fee,
+		Balance:     balance,
+	}
+
+	if amount != nil {
+		w.Amount = *amount
+	} else {
+		w.Amount = balance.Sub(reserve).Sub(fee)
+	}
+
+	if !w.Amount.IsPositive() {
+		return nil, fmt.Errorf(
+			"nothing to withdraw from %s: balance %s%s, reserve %s%s, fee %s%s",
+			a.Address,
+			balance.String(), a.Denom,
+			reserve.String(), a.Denom,
+			fee.String(), a.Denom,
+		)
+	}
+
+	if w.Amount.Add(fee).GT(balance) {
+		return nil, fmt.Errorf(
+			"insufficient balance: have %s%s, need %s%s",
+			balance.String(), a.Denom,
+			w.Amount.Add(fee).String(), a.Denom,
+		)
+	}
+
+	return w, nil
+}
+
+func (a *Account) sendArgs(from, to, amount string) []string {
+	return []string{
+		"tx", "bank", "send", from, to, amount,
+		"--keyring-backend", "test",
+		"--keyring-dir", filepath.Join(a.Home, a.Key.Dir),
+		"--node", a.RPC,
+		"--chain-id", a.ChainID,
+	}
+}
+
+func (w *Withdrawal) Print() {
+	pterm.DefaultSection.WithIndentCharacter("💈").
+		Printf("withdrawal from %s (%s)", w.Account.Address, w.Account.Chain)
+
+	d := w.Account.Denom
+	td := pterm.TableData{
+		{"destination", w.Destination},
+		{"amount", w.Amount.String() + d},
+		{"gas", w.Gas.String()},
+		{"fee", w.Fee.String() + d},
+		{"current balance", w.Balance.String() + d},
+		{"remaining balance", w.Balance.Sub(w.Amount).Sub(w.Fee).String() + d},
+	}
+	_ = pterm.DefaultTable.WithData(td).Render()
+}
+
+func (w *Withdrawal) cmd() *exec.Cmd {
+	a := w.Account
+	args := append(
+		a.sendArgs(a.Key.ID, w.Destination, w.Amount.String()+a.Denom),
+		"--gas", w.Gas.String(),
+		"--fees", w.Fee.String()+a.Denom,
+	)
+
+	return exec.Command(a.binary(), args...)
+}
+
+// Broadcast signs the withdrawal with the keyring key and waits for it to be
+// included in a block. Interactive withdrawals ask for confirmation
+func (w *Withdrawal) Broadcast(interactive bool) error {
+	c := w.cmd()
+
+	var out string
+	if interactive {
+		o, err := bash.ExecCommandWithInput(c, "signatures")
+		if err != nil {
+			return err
+		}
+		out = o
+	} else {
+		c.Args = append(c.Args, "--yes")
+		o, err := bash.ExecCommandWithStdout(c)
+		if err != nil {
+			return err
+		}
+		out = o.String()
+	}
+
+	txHash, err := bash.ExtractTxHash(out)
+	if err != nil {
+		return err
+	}
+
+	return tx.MonitorTransaction(w.Account.RPC, txHash)
+}
+
+// ParseAmount accepts either a plain integer or a coin string in the provided
+// denom, empty values are treated as zero
+func ParseAmount(v, denom string) (cosmossdkmath.Int, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return cosmossdkmath.ZeroInt(), nil
+	}
+
+	if amt, ok := cosmossdkmath.NewIntFromString(v); ok {
+		return amt, nil
+	}
+
+	coin, err := cosmossdktypes.ParseCoinNormalized(v)
+	if err != nil {
+		return cosmossdkmath.Int{}, err
+	}
+	if coin.Denom != denom {
+		return cosmossdkmath.Int{}, fmt.Errorf("invalid denom in %s, expected %s", v, denom)
+	}
+
+	return coin.Amount, nil
+}
+
+// Sweep withdraws the rewards of every account with an enabled sweep whose
+// balance above the reserve exceeds the sweep threshold
+func Sweep(cfg roller.RollappConfig, l *log.Logger) {
+	sweeps := map[string]roller.RewardsSweep{
+		ChainRollapp: cfg.Rewards.RollappSweep,
+		ChainHub:     cfg.Rewards.HubSweep,
+	}
+
+	for chain, s := range sweeps {
+		if !s.Enabled {
+			continue
+		}
+
+		err := sweep(cfg, chain, s, l)
+		if err != nil {
+			l.Printf("failed to sweep %s rewards: %v\n", chain, err)
+		}
+	}
+}
+
+func sweep(cfg roller.RollappConfig, chain string, s roller.RewardsSweep, l *log.Logger) error {
+	a, err := GetAccount(cfg, chain)
+	if err != nil {
+		return err
+	}
+
+	threshold, err := ParseAmount(s.Threshold, a.Denom)
+	if err != nil {
+		return fmt.Errorf("invalid threshold: %w", err)
+	}
+
+	reserve, err := ParseAmount(s.Reserve, a.Denom)
+	if err != nil {
+		return fmt.Errorf("invalid reserve: %w", err)
+	}
+
+	if chain == ChainHub {
+		reserve, err = hubReserve(cfg, reserve)
+		if err != nil {
+			return err
+		}
+	}
+
+	balance, err := a.Balance()
+	if err != nil {
+		return err
+	}
+
+	if !balance.Sub(reserve).GT(threshold) {
+		return nil
+	}
+
+	w, err := NewWithdrawal(a, s.Destination, nil, reserve)
+	if err != nil {
+		return err
+	}
+
+	err = w.Broadcast(false)
+	if err != nil {
+		return err
+	}
+
+	l.Printf(
+		"swept %s%s from %s to %s\n",
+		w.Amount.String(),
+		a.Denom,
+		a.Address,
+		w.Destination,
+	)
+
+	return nil
+}
+
+// HubFeeBudget is the amount kept in the hub sequencer account to pay for the
+// fees of bond top ups and other sequencer transactions
+func HubFeeBudget() cosmossdkmath.Int {
+	return cosmossdkmath.NewInt(consts.DefaultTxFee).MulRaw(hubFeeBudgetTxs)
+}
+
+// hubReserve returns the amount to keep in the hub sequencer account, which is
+// at least the fee budget and the bond policy deficit. The hub account can't
+// be swept without an explicit reserve as it pays for the bond top ups
+func hubReserve(cfg roller.RollappConfig, reserve cosmossdkmath.Int) (cosmossdkmath.Int, error) {
+	if !reserve.IsPositive() {
+		return cosmossdkmath.Int{}, errors.New(
+			"the hub sequencer account pays the bond top ups and fees, set a reserve to sweep it",
+		)
+	}
+
+	minReserve := HubFeeBudget()
+	if cfg.BondPolicy.Enabled {
+		status, err := sequencer.GetBondPolicyStatus(cfg)
+		if err != nil {
+			return cosmossdkmath.Int{}, fmt.Errorf("failed to retrieve the bond policy status: %w", err)
+		}
+		minReserve = minReserve.Add(status.Deficit)
+	}
+
+	return cosmossdkmath.MaxInt(reserve, minReserve), nil
+}
@@ -0,0 +1,90 @@
+package rollapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// ResetModeFull removes all node data and the address book, the node
+	// resyncs from scratch
+	ResetModeFull = "full"
+	// ResetModeKeepAddrBook removes all node data but keeps the known peers
+	ResetModeKeepAddrBook = "keep-addrbook"
+
+	privValidatorStateFile = "priv_validator_state.json"
+	addrBookFile           = "addrbook.json"
+)
+
+// there is no mode resetting only the application state, dymint keeps the
+// height and app hash of the last block and doesn't replay blocks into an
+// empty application
+var ResetModes = []string{ResetModeFull, ResetModeKeepAddrBook}
+
+type ResetResult struct {
+	BackupDir string
+	Removed   []string
+}
+
+// Reset wipes the rollapp data directory according to the mode. Everything
+// that is removed is moved into a backup directory next to the data directory
+// first. The validator state is always kept in place so the node can't sign
+// conflicting blocks after the reset, keys, genesis and configuration files
+// are never touched
+func Reset(home, mode string) (*ResetResult, error) {
+	rollappDir := GetHomeDir(home)
+	dataDir := filepath.Join(rollappDir, "data")
+
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the data directory: %w", err)
+	}
+
+	var toRemove []string
+	switch mode {
+	case ResetModeFull, ResetModeKeepAddrBook:
+		for _, e := range entries {
+			if e.Name() == privValidatorStateFile {
+				continue
+			}
+			toRemove = append(toRemove, filepath.Join(dataDir, e.Name()))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported reset mode %s, use one of %v", mode, ResetModes)
+	}
+
+	addrBook := filepath.Join(rollappDir, "config", addrBookFile)
+	if _, err := os.Stat(addrBook); err == nil && mode == ResetModeFull {
+		toRemove = append(toRemove, addrBook)
+	}
+
+	res := &ResetResult{
+		BackupDir: filepath.Join(rollappDir, fmt.Sprintf("data.reset-%d", time.Now().Unix())),
+	}
+	err = os.MkdirAll(res.BackupDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	// the validator state is copied to the backup as a reference, the
+	// original is never moved
+	pvs := filepath.Join(dataDir, privValidatorStateFile)
+	if b, err := os.ReadFile(pvs); err == nil {
+		err = os.WriteFile(filepath.Join(res.BackupDir, privValidatorStateFile), b, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up the validator state: %w", err)
+		}
+	}
+
+	for _, p := range toRemove {
+		err := os.Rename(p, filepath.Join(res.BackupDir, filepath.Base(p)))
+		if err != nil {
+			return res, fmt.Errorf("failed to move %s to the backup: %w", p, err)
+		}
+		res.Removed = append(res.Removed, p)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package rollapp
+
+import (
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+// BlockDescriptor is the state root the sequencer committed to for a single
+// rollapp block
+type BlockDescriptor struct {
+	Height    string    `json:"height"`
+	StateRoot []byte    `json:"stateRoot"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// StateInfo is a state update posted by the sequencer to the hub, DAPath
+// points at the batch the state update was derived from
+type StateInfo struct {
+	StateInfoIndex StateInfoIndex `json:"stateInfoIndex"`
+	Sequencer      string         `json:"sequencer"`
+	StartHeight    string         `json:"startHeight"`
+	NumBlocks      string         `json:"numBlocks"`
+	DAPath         string         `json:"DAPath"`
+	CreationHeight string         `json:"creationHeight"`
+	Status         string         `json:"status"`
+	BDs            struct {
+		BD []BlockDescriptor `json:"BD"`
+	} `json:"BDs"`
+}
+
+type StateInfoResponse struct {
+	StateInfo StateInfo `json:"stateInfo"`
+}
+
+// GetStateInfoCmd queries the state update with the given index, the latest
+// state update is queried when the index is empty
+func GetStateInfoCmd(raID, index string, hd consts.HubData) *exec.Cmd {
+	args := []string{"q", "rollapp", "state", raID}
+	if index != "" {
+		args = append(args, "--index", index)
+	}
+	args = append(args, "-o", "json", "--node", hd.RPC_URL, "--chain-id", hd.ID)
+
+	return exec.Command(consts.Executables.Dymension, args...)
+}
+
+func GetStateInfo(raID, index string, hd consts.HubData) (*StateInfo, error) {
+	out, err := bash.ExecCommandWithStdout(GetStateInfoCmd(raID, index, hd))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp StateInfoResponse
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.StateInfo, nil
+}
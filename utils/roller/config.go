@@ -16,6 +16,7 @@ type RollappConfig struct {
 	// new roller.toml
 	Home          string `toml:"home"`
 	RollerVersion string `toml:"roller_version"`
+	SchemaVersion int    `toml:"schema_version"`
 
 	NodeType string `toml:"node_type"`
 
@@ -34,8 +35,63 @@ type RollappConfig struct {
 	Decimals             uint
 	MinGasPrices         string `toml:"minimum_gas_prices"`
 
-	HubData consts.HubData
-	DA      consts.DaData
+	HubData consts.HubData `toml:"HubData"`
+	DA      consts.DaData  `toml:"DA"`
+
+	BondPolicy BondPolicy     `toml:"bond_policy"`
+	Rewards    RewardsConfig  `toml:"rewards"`
+	Standby    StandbyConfig  `toml:"standby"`
+	Snapshots  SnapshotConfig `toml:"snapshots"`
+
+	// Binaries holds the pinned versions of the installed binaries, keyed by
+	// the binary name
+	Binaries map[string]string `toml:"binaries"`
+}
+
+// BondPolicy describes how the health agent keeps the sequencer bond above
+// the minimum required by the hub. Amounts are in the hub base denom (adym)
+type BondPolicy struct {
+	Enabled    bool   `toml:"enabled"`
+	TargetBond string `toml:"target_bond"`
+	MinBuffer  string `toml:"min_buffer"`
+}
+
+// RewardsConfig holds the sequencer rewards address on the rollapp and the
+// automatic sweep settings for the rollapp rewards and hub sequencer accounts
+type RewardsConfig struct {
+	Address      string       `toml:"address"`
+	RollappSweep RewardsSweep `toml:"rollapp_sweep"`
+	HubSweep     RewardsSweep `toml:"hub_sweep"`
+}
+
+// StandbyConfig describes when a standby full node considers the active
+// sequencer dead and promotes itself. Durations use the time.ParseDuration
+// format, empty values fall back to the defaults
+type StandbyConfig struct {
+	StateUpdateTimeout string `toml:"state_update_timeout"`
+	BlockTimeout       string `toml:"block_timeout"`
+	CheckInterval      string `toml:"check_interval"`
+	FailureThreshold   int    `toml:"failure_threshold"`
+	LeaseFile          string `toml:"lease_file"`
+	LeaseTTL           string `toml:"lease_ttl"`
+	FenceCommand       string `toml:"fence_command"`
+}
+
+// SnapshotConfig holds the retention of local snapshots, a Keep of 0 keeps
+// all snapshots and an empty MaxAge disables age based pruning
+type SnapshotConfig struct {
+	Keep   int    `toml:"keep"`
+	MaxAge string `toml:"max_age"`
+}
+
+// RewardsSweep describes when the health agent sweeps an account: once the
+// balance above the reserve exceeds the threshold, everything above the
+// reserve is sent to the destination. Amounts are in the chain base denom
+type RewardsSweep struct {
+	Enabled     bool   `toml:"enabled"`
+	Destination string `toml:"destination"`
+	Threshold   string `toml:"threshold"`
+	Reserve     string `toml:"reserve"`
 }
 
 func PrintTokenSupplyLine(rollappConfig RollappConfig) {
@@ -85,9 +141,13 @@ func (c RollappConfig) Validate() error {
 	// 	return err
 	// }
 
-	if !IsValidDAType(string(c.DA.Backend)) {
-		fmt.Println(c.DA.Backend)
-		return fmt.Errorf("invalid DA type: %s. supported types %s", c.DA, SupportedDas)
+	if !IsValidDAType(string(c.DA.Backend)) && !IsDAPluginInstalled(c.Home, string(c.DA.Backend)) {
+		return fmt.Errorf(
+			"invalid DA type: %s. supported types %s, or a plugin installed in %s",
+			c.DA.Backend,
+			SupportedDas,
+			filepath.Join(c.Home, consts.ConfigDirName.DAPlugins),
+		)
 	}
 
 	return nil
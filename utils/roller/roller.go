@@ -45,9 +45,18 @@ func CreateConfigFile(home string) (bool, error) {
 }
 
 // TODO: should be called from root command
+// LoadConfig migrates roller.toml to the current schema version before
+// loading it
 func LoadConfig(root string) (RollappConfig, error) {
 	var rc RollappConfig
-	tomlBytes, err := os.ReadFile(filepath.Join(root, consts.RollerConfigFileName))
+	configPath := filepath.Join(root, consts.RollerConfigFileName)
+
+	_, err := MigrateSchema(configPath)
+	if err != nil {
+		return rc, err
+	}
+
+	tomlBytes, err := os.ReadFile(configPath)
 	if err != nil {
 		return rc, err
 	}
@@ -60,6 +69,7 @@ func LoadConfig(root string) (RollappConfig, error) {
 }
 
 func WriteConfig(rlpCfg RollappConfig) error {
+	rlpCfg.SchemaVersion = SchemaVersion
 	tomlBytes, err := naoinatoml.Marshal(rlpCfg)
 	if err != nil {
 		return err
@@ -69,12 +79,7 @@ func WriteConfig(rlpCfg RollappConfig) error {
 }
 
 func LoadHubData(root string) (consts.HubData, error) {
-	var config RollappConfig
-	tomlBytes, err := os.ReadFile(filepath.Join(root, consts.RollerConfigFileName))
-	if err != nil {
-		return config.HubData, err
-	}
-	err = naoinatoml.Unmarshal(tomlBytes, &config)
+	config, err := LoadConfig(root)
 	if err != nil {
 		return config.HubData, err
 	}
@@ -0,0 +1,255 @@
+package roller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+)
+
+// SchemaVersion is the version of the roller.toml layout written by this
+// roller binary. Bump it together with a new entry in schemaMigrations whenever
+// the shape of roller.toml changes
+const SchemaVersion = 4
+
+const schemaVersionKey = "schema_version"
+
+// schemaMigration upgrades a roller.toml tree from Version-1 to Version
+type schemaMigration struct {
+	Version     int
+	Description string
+	Migrate     func(tree *toml.Tree) error
+}
+
+// schemaMigrations is the ordered registry of roller.toml migrations, every
+// migration must be idempotent
+var schemaMigrations = []schemaMigration{
+	{
+		Version:     1,
+		Description: "merge the hub and da tables into [HubData] and [DA]",
+		Migrate:     migrateDataTables,
+	},
+	{
+		Version:     2,
+		Description: "replace the legacy rollapp init keys",
+		Migrate:     migrateLegacyInitKeys,
+	},
+	{
+		// the [binaries] table is optional and isn't written by the
+		// migration, the version bump only makes older releases, which
+		// reject the table, refuse the file
+		Version:     3,
+		Description: "no-op bump so older releases reject the [binaries] table",
+		Migrate:     func(*toml.Tree) error { return nil },
+	},
+	{
+		Version:     4,
+		Description: "record the celestia namespace of the rollapp",
+		Migrate:     migrateDANamespace,
+	},
+}
+
+// ErrSchemaTooNew is returned when roller.toml was written by a newer roller
+type ErrSchemaTooNew struct {
+	Path    string
+	Version int
+}
+
+func (e *ErrSchemaTooNew) Error() string {
+	return fmt.Sprintf(
+		"%s has schema version %d, this roller binary supports up to version %d, please upgrade roller",
+		e.Path,
+		e.Version,
+		SchemaVersion,
+	)
+}
+
+// GetSchemaVersion returns the schema version of a roller.toml tree, files
+// written before schema versioning are version 0
+func GetSchemaVersion(tree *toml.Tree) (int, error) {
+	if !tree.Has(schemaVersionKey) {
+		return 0, nil
+	}
+
+	switch v := tree.Get(schemaVersionKey).(type) {
+	case int64:
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("invalid %s: %v", schemaVersionKey, v)
+	}
+}
+
+// MigrateSchema upgrades the roller.toml at path to the current schema
+// version. The original file is backed up next to it before it's rewritten,
+// the returned backup path is empty when no migration was needed
+func MigrateSchema(path string) (string, error) {
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load %s: %v", path, err)
+	}
+
+	// a freshly created roller.toml is populated by init
+	if len(tree.Keys()) == 0 {
+		return "", nil
+	}
+
+	from, err := GetSchemaVersion(tree)
+	if err != nil {
+		return "", err
+	}
+	if from > SchemaVersion {
+		return "", &ErrSchemaTooNew{Path: path, Version: from}
+	}
+	if from == SchemaVersion {
+		return "", nil
+	}
+
+	for _, m := range schemaMigrations {
+		if m.Version <= from {
+			continue
+		}
+
+		err = m.Migrate(tree)
+		if err != nil {
+			return "", fmt.Errorf("roller.toml migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+	}
+	tree.Set(schemaVersionKey, int64(SchemaVersion))
+
+	backup := fmt.Sprintf("%s.backup-%d", path, time.Now().Unix())
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(backup, b, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	err = tomlconfig.WriteTomlTreeToFile(tree, path)
+	if err != nil {
+		return "", err
+	}
+
+	pterm.Info.Printf(
+		"migrated %s from schema version %d to %d, the previous file is saved as %s\n",
+		path,
+		from,
+		SchemaVersion,
+		backup,
+	)
+
+	return backup, nil
+}
+
+// migrateDataTables merges the tables written by older releases into the
+// tables roller edits in place. Values from the canonical tables win because
+// they hold the most recent in-place updates
+func migrateDataTables(tree *toml.Tree) error {
+	// the earliest releases only stored the da backend
+	if backend, ok := tree.Get("da").(string); ok {
+		_ = tree.Delete("da")
+		if !tree.Has("DA.backend") {
+			tree.Set("DA.backend", backend)
+		}
+	}
+
+	for _, t := range [][2]string{
+		{"hub_data", "HubData"},
+		{"d_a", "DA"},
+		{"da", "DA"},
+	} {
+		err := mergeTable(tree, t[0], t[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mergeTable(tree *toml.Tree, from, to string) error {
+	if !tree.Has(from) {
+		return nil
+	}
+
+	src, ok := tree.Get(from).(*toml.Tree)
+	if !ok {
+		return fmt.Errorf("%s is not a table", from)
+	}
+
+	for _, k := range src.Keys() {
+		key := to + "." + k
+		if !tree.Has(key) {
+			tree.Set(key, src.Get(k))
+		}
+	}
+
+	return tree.Delete(from)
+}
+
+// migrateLegacyInitKeys replaces the keys written by the mock rollapp init of
+// older releases
+func migrateLegacyInitKeys(tree *toml.Tree) error {
+	if tree.Has("denom_exponent") {
+		v := tree.Get("denom_exponent")
+		_ = tree.Delete("denom_exponent")
+
+		if !tree.Has("decimals") {
+			s := fmt.Sprint(v)
+			d, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid denom_exponent %s: %w", s, err)
+			}
+			tree.Set("decimals", d)
+		}
+	}
+
+	// the logos are part of the rollapp metadata on the hub
+	for _, k := range []string{"logo_data_uri", "denom_logo_data_uri"} {
+		if tree.Has(k) {
+			_ = tree.Delete(k)
+		}
+	}
+
+	return nil
+}
+
+// migrateDANamespace copies the celestia namespace from dymint.toml, older
+// releases only stored it there
+func migrateDANamespace(tree *toml.Tree) error {
+	if tree.Has("DA.namespace_id") {
+		return nil
+	}
+	if backend, _ := tree.Get("DA.backend").(string); backend != string(consts.Celestia) {
+		return nil
+	}
+
+	home, _ := tree.Get("home").(string)
+	if home == "" {
+		return nil
+	}
+
+	// nodes that weren't set up yet don't have a dymint.toml
+	dymintCfg, err := toml.LoadFile(
+		filepath.Join(home, consts.ConfigDirName.Rollapp, "config", "dymint.toml"),
+	)
+	if err != nil {
+		return nil
+	}
+
+	if ns, ok := dymintCfg.Get("namespace_id").(string); ok && ns != "" {
+		tree.Set("DA.namespace_id", ns)
+	}
+
+	return nil
+}
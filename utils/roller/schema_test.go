@@ -0,0 +1,103 @@
+package roller
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+var updateGoldens = flag.Bool("update", false, "rewrite the golden files")
+
+const (
+	schemaTestdataDir = "testdata/schema"
+	homePlaceholder   = "PLACEHOLDER_HOME"
+)
+
+// setupSchemaHome copies the roller.toml of the fixture, and its dymint.toml
+// when present, into a temporary roller home
+func setupSchemaHome(t *testing.T, fixture string) (string, []byte) {
+	t.Helper()
+
+	home := t.TempDir()
+	dir := filepath.Join(schemaTestdataDir, fixture)
+
+	b, err := os.ReadFile(filepath.Join(dir, consts.RollerConfigFileName))
+	require.NoError(t, err)
+	input := []byte(strings.ReplaceAll(string(b), homePlaceholder, home))
+	require.NoError(t, os.WriteFile(GetConfigPath(home), input, 0o644))
+
+	dymint, err := os.ReadFile(filepath.Join(dir, "dymint.toml"))
+	if err == nil {
+		cfgDir := filepath.Join(home, consts.ConfigDirName.Rollapp, "config")
+		require.NoError(t, os.MkdirAll(cfgDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(cfgDir, "dymint.toml"), dymint, 0o644))
+	} else {
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+
+	return home, input
+}
+
+func TestMigrateSchema(t *testing.T) {
+	fixtures := []string{"v0", "v0-da-backend", "v1", "v2", "v3", "v3-avail"}
+
+	for _, fixture := range fixtures {
+		t.Run(
+			fixture, func(t *testing.T) {
+				home, input := setupSchemaHome(t, fixture)
+				path := GetConfigPath(home)
+
+				backup, err := MigrateSchema(path)
+				require.NoError(t, err)
+				require.NotEmpty(t, backup)
+
+				b, err := os.ReadFile(backup)
+				require.NoError(t, err)
+				require.Equal(t, string(input), string(b), "the backup must hold the original file")
+
+				b, err = os.ReadFile(path)
+				require.NoError(t, err)
+				got := strings.ReplaceAll(string(b), home, homePlaceholder)
+
+				golden := filepath.Join(schemaTestdataDir, fixture, "roller.golden.toml")
+				if *updateGoldens {
+					require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				require.Equal(t, string(want), got)
+
+				// the migrations are idempotent, a migrated file is left alone
+				backup, err = MigrateSchema(path)
+				require.NoError(t, err)
+				require.Empty(t, backup)
+			},
+		)
+	}
+}
+
+func TestMigrateSchemaTooNew(t *testing.T) {
+	home := t.TempDir()
+	path := GetConfigPath(home)
+	input := []byte("schema_version = 5\nnode_type = \"sequencer\"\n")
+	require.NoError(t, os.WriteFile(path, input, 0o644))
+
+	backup, err := MigrateSchema(path)
+	require.Empty(t, backup)
+
+	var tooNew *ErrSchemaTooNew
+	require.True(t, errors.As(err, &tooNew))
+	require.Equal(t, 5, tooNew.Version)
+	require.Equal(t, path, tooNew.Path)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(input), string(b), "a newer file must not be rewritten")
+}
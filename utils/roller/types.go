@@ -2,4 +2,4 @@ package roller
 
 import "github.com/dymensionxyz/roller/cmd/consts"
 
-var SupportedDas = []consts.DAType{consts.Celestia, consts.Local}
+var SupportedDas = []consts.DAType{consts.Celestia, consts.Avail, consts.Local}
@@ -2,6 +2,8 @@ package roller
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"unicode"
 
@@ -16,6 +18,27 @@ func IsValidDAType(t string) bool {
 	return false
 }
 
+// DAPluginPath returns the path of the executable implementing the DA plugin
+// with the given name
+func DAPluginPath(home, name string) string {
+	return filepath.Join(home, consts.ConfigDirName.DAPlugins, name)
+}
+
+// IsDAPluginInstalled reports whether an executable DA plugin with the given
+// name is installed in the roller home
+func IsDAPluginInstalled(home, name string) bool {
+	if name == "" || filepath.Base(name) != name {
+		return false
+	}
+
+	fi, err := os.Stat(DAPluginPath(home, name))
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode().IsRegular() && fi.Mode().Perm()&0o111 != 0
+}
+
 func IsValidVMType(t string) bool {
 	switch consts.VMType(t) {
 	case consts.SDK_ROLLAPP, consts.EVM_ROLLAPP:
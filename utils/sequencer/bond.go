@@ -0,0 +1,345 @@
+package sequencer
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+type BondAction string
+
+const (
+	BondActionIncrease BondAction = "increase-bond"
+	BondActionDecrease BondAction = "decrease-bond"
+	BondActionUnbond   BondAction = "unbond"
+)
+
+var gasEstimateRegex = regexp.MustCompile(`gas estimate:\s*(\d+)`)
+
+// BondTxSimulation contains the outcome of a simulated bond transaction
+type BondTxSimulation struct {
+	Action           BondAction
+	Address          string
+	GasEstimate      cosmossdkmath.Int
+	Fee              cosmossdkmath.Int
+	MinFee           cosmossdkmath.Int
+	CurrentBond      cosmossdkmath.Int
+	ResultingBond    cosmossdkmath.Int
+	Balance          cosmossdkmath.Int
+	RemainingBalance cosmossdkmath.Int
+}
+
+// BondPolicyStatus describes the current bond in relation to the bond policy
+type BondPolicyStatus struct {
+	Address      string
+	CurrentBond  cosmossdkmath.Int
+	MinBond      cosmossdkmath.Int
+	RequiredBond cosmossdkmath.Int
+	Deficit      cosmossdkmath.Int
+}
+
+func getBondTxArgs(cfg roller.RollappConfig, action BondAction, amount, from string) []string {
+	args := []string{"tx", "sequencer", string(action)}
+	if action != BondActionUnbond {
+		args = append(args, amount)
+	}
+
+	args = append(
+		args,
+		"--keyring-backend", "test",
+		"--from", from,
+		"--keyring-dir", filepath.Join(cfg.Home, consts.ConfigDirName.HubKeys),
+		"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		"--node", cfg.HubData.RPC_URL,
+		"--chain-id", cfg.HubData.ID,
+	)
+
+	return args
+}
+
+// GetBondTxCmd returns the command that broadcasts a bond transaction signed by
+// the hub sequencer key
+func GetBondTxCmd(cfg roller.RollappConfig, action BondAction, amount string) *exec.Cmd {
+	return exec.Command(
+		consts.Executables.Dymension,
+		getBondTxArgs(cfg, action, amount, consts.KeysIds.HubSequencer)...,
+	)
+}
+
+// SimulateBondTx runs the bond transaction with --dry-run and calculates the
+// resulting bond and the sequencer account balance after the transaction
+func SimulateBondTx(
+	cfg roller.RollappConfig,
+	action BondAction,
+	amount string,
+) (*BondTxSimulation, error) {
+	var amt cosmossdkmath.Int
+	if action != BondActionUnbond {
+		coin, err := cosmossdktypes.ParseCoinNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		if coin.Denom != consts.Denoms.Hub {
+			return nil, fmt.Errorf("invalid denom, only '%s' is supported", consts.Denoms.Hub)
+		}
+		amt = coin.Amount
+	}
+
+	address, err := GetHubSequencerAddress(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// --dry-run doesn't access the keyring, so the address has to be passed
+	// to --from instead of the key name
+	args := append(getBondTxArgs(cfg, action, amount, address), "--dry-run")
+	c := exec.Command(consts.Executables.Dymension, args...)
+
+	out, err := bash.ExecCommandWithStdErr(c)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := parseGasEstimate(out.String())
+	if err != nil {
+		return nil, err
+	}
+
+	bond, err := GetSequencerBond(address, cfg.HubData)
+	if err != nil {
+		return nil, err
+	}
+	currentBond := bond.AmountOf(consts.Denoms.Hub)
+
+	balance, err := keys.QueryBalance(
+		keys.ChainQueryConfig{
+			Binary: consts.Executables.Dymension,
+			Denom:  consts.Denoms.Hub,
+			RPC:    cfg.HubData.RPC_URL,
+		}, address,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := cosmossdkmath.NewInt(consts.DefaultTxFee)
+	minFee := cosmossdkmath.ZeroInt()
+	if gp, ok := cosmossdkmath.NewIntFromString(cfg.HubData.GAS_PRICE); ok {
+		minFee = gp.Mul(gas)
+	}
+
+	s := &BondTxSimulation{
+		Action:      action,
+		Address:     address,
+		GasEstimate: gas,
+		Fee:         fee,
+		MinFee:      minFee,
+		CurrentBond: currentBond,
+		Balance:     cosmossdkmath.NewIntFromBigInt(balance.Amount),
+	}
+
+	switch action {
+	case BondActionIncrease:
+		s.ResultingBond = currentBond.Add(amt)
+		s.RemainingBalance = s.Balance.Sub(fee).Sub(amt)
+	case BondActionDecrease:
+		if amt.GT(currentBond) {
+			return nil, fmt.Errorf(
+				"decrease amount %s is higher than the current bond %s",
+				amt.String(),
+				currentBond.String(),
+			)
+		}
+		s.ResultingBond = currentBond.Sub(amt)
+		s.RemainingBalance = s.Balance.Sub(fee)
+	case BondActionUnbond:
+		s.ResultingBond = cosmossdkmath.ZeroInt()
+		s.RemainingBalance = s.Balance.Sub(fee)
+	}
+
+	return s, nil
+}
+
+func (s *BondTxSimulation) Print() {
+	pterm.DefaultSection.WithIndentCharacter("💈").
+		Printf("%s simulation for %s", s.Action, s.Address)
+
+	td := pterm.TableData{
+		{"gas estimate", s.GasEstimate.String()},
+		{"fee", s.Fee.String() + consts.Denoms.Hub},
+		{"current bond", s.CurrentBond.String() + consts.Denoms.Hub},
+		{"resulting bond", s.ResultingBond.String() + consts.Denoms.Hub},
+		{"current balance", s.Balance.String() + consts.Denoms.Hub},
+		{"remaining balance", s.RemainingBalance.String() + consts.Denoms.Hub},
+	}
+	_ = pterm.DefaultTable.WithData(td).Render()
+
+	if s.MinFee.GT(s.Fee) {
+		pterm.Warning.Printf(
+			"the estimated gas requires at least %s%s in fees, the transaction is likely to fail\n",
+			s.MinFee.String(),
+			consts.Denoms.Hub,
+		)
+	}
+
+	if s.RemainingBalance.IsNegative() {
+		pterm.Warning.Println("the sequencer account balance is insufficient for this transaction")
+	}
+}
+
+func parseGasEstimate(out string) (cosmossdkmath.Int, error) {
+	m := gasEstimateRegex.FindStringSubmatch(out)
+	if len(m) != 2 {
+		return cosmossdkmath.Int{}, errors.New("gas estimate not found in the simulation output")
+	}
+
+	gas, ok := cosmossdkmath.NewIntFromString(m[1])
+	if !ok {
+		return cosmossdkmath.Int{}, fmt.Errorf("invalid gas estimate: %s", m[1])
+	}
+
+	return gas, nil
+}
+
+// parsePolicyAmount accepts either a plain integer or a coin string in the hub
+// base denom, empty values are treated as zero
+func parsePolicyAmount(v string) (cosmossdkmath.Int, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return cosmossdkmath.ZeroInt(), nil
+	}
+
+	if amt, ok := cosmossdkmath.NewIntFromString(v); ok {
+		return amt, nil
+	}
+
+	coin, err := cosmossdktypes.ParseCoinNormalized(v)
+	if err != nil {
+		return cosmossdkmath.Int{}, err
+	}
+	if coin.Denom != consts.Denoms.Hub {
+		return cosmossdkmath.Int{}, fmt.Errorf(
+			"invalid denom in %s, only '%s' is supported",
+			v,
+			consts.Denoms.Hub,
+		)
+	}
+
+	return coin.Amount, nil
+}
+
+// GetBondPolicyStatus compares the current sequencer bond with the bond
+// required by the policy, which is the higher of the target bond and the hub
+// minimum bond increased by the minimum buffer
+func GetBondPolicyStatus(cfg roller.RollappConfig) (*BondPolicyStatus, error) {
+	target, err := parsePolicyAmount(cfg.BondPolicy.TargetBond)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target_bond: %w", err)
+	}
+
+	buffer, err := parsePolicyAmount(cfg.BondPolicy.MinBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min_buffer: %w", err)
+	}
+
+	minBond, err := GetMinSequencerBondInBaseDenom(cfg.HubData)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := GetHubSequencerAddress(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bond, err := GetSequencerBond(address, cfg.HubData)
+	if err != nil {
+		return nil, err
+	}
+
+	required := cosmossdkmath.MaxInt(target, minBond.Amount.Add(buffer))
+	current := bond.AmountOf(consts.Denoms.Hub)
+
+	deficit := cosmossdkmath.ZeroInt()
+	if current.LT(required) {
+		deficit = required.Sub(current)
+	}
+
+	return &BondPolicyStatus{
+		Address:      address,
+		CurrentBond:  current,
+		MinBond:      minBond.Amount,
+		RequiredBond: required,
+		Deficit:      deficit,
+	}, nil
+}
+
+// EnforceBondPolicy increases the sequencer bond by the policy deficit, the
+// transaction is broadcasted without confirmation. Returns the policy status
+// from before the transaction
+func EnforceBondPolicy(cfg roller.RollappConfig) (*BondPolicyStatus, error) {
+	status, err := GetBondPolicyStatus(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Deficit.IsPositive() {
+		return status, nil
+	}
+
+	balance, err := keys.QueryBalance(
+		keys.ChainQueryConfig{
+			Binary: consts.Executables.Dymension,
+			Denom:  consts.Denoms.Hub,
+			RPC:    cfg.HubData.RPC_URL,
+		}, status.Address,
+	)
+	if err != nil {
+		return status, err
+	}
+
+	needed := status.Deficit.Add(cosmossdkmath.NewInt(consts.DefaultTxFee))
+	if cosmossdkmath.NewIntFromBigInt(balance.Amount).LT(needed) {
+		return status, fmt.Errorf(
+			"insufficient balance to top up the bond: have %s%s, need %s%s",
+			balance.Amount.String(),
+			consts.Denoms.Hub,
+			needed.String(),
+			consts.Denoms.Hub,
+		)
+	}
+
+	amount := status.Deficit.String() + consts.Denoms.Hub
+	c := GetBondTxCmd(cfg, BondActionIncrease, amount)
+	c.Args = append(c.Args, "--yes")
+
+	out, err := bash.ExecCommandWithStdout(c)
+	if err != nil {
+		return status, err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return status, err
+	}
+
+	err = tx.MonitorTransaction(cfg.HubData.RPC_URL, txHash)
+	if err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
@@ -0,0 +1,41 @@
+package sequencer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+)
+
+// GetDAConfig returns the dymint da_config, a JSON object stored as a string
+func GetDAConfig(home string) (map[string]any, error) {
+	current, err := tomlconfig.GetKeyFromFile(GetDymintFilePath(home), "da_config")
+	if err != nil {
+		return nil, err
+	}
+
+	var daCfg map[string]any
+	err = json.Unmarshal([]byte(current), &daCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse da_config: %w", err)
+	}
+
+	return daCfg, nil
+}
+
+// SetDAConfigField replaces a single field of the dymint da_config, the rest
+// of the DA configuration stays untouched
+func SetDAConfigField(home, key string, value any) error {
+	daCfg, err := GetDAConfig(home)
+	if err != nil {
+		return err
+	}
+	daCfg[key] = value
+
+	updated, err := json.Marshal(daCfg)
+	if err != nil {
+		return err
+	}
+
+	return tomlconfig.UpdateFieldInFile(GetDymintFilePath(home), "da_config", string(updated))
+}
@@ -0,0 +1,81 @@
+package sequencer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dymensionseqtypes "github.com/dymensionxyz/dymension/v3/x/sequencer/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+)
+
+// ValidateSuccessor checks that the sequencer with the provided address can
+// take over as the proposer of the rollapp: it has to be registered for the
+// rollapp, bonded, not jailed and hold at least the minimum bond
+func ValidateSuccessor(addr, raID string, hd consts.HubData) (*Info, error) {
+	info, err := GetSequencerInfo(addr, hd)
+	if err != nil {
+		return nil, fmt.Errorf("sequencer %s is not registered: %w", addr, err)
+	}
+
+	if info.RollappId != raID {
+		return nil, fmt.Errorf(
+			"sequencer %s is registered for %s, not %s",
+			addr,
+			info.RollappId,
+			raID,
+		)
+	}
+
+	if info.Jailed {
+		return nil, fmt.Errorf("sequencer %s is jailed", addr)
+	}
+
+	if info.Status != dymensionseqtypes.Bonded.String() {
+		return nil, fmt.Errorf("sequencer %s is not bonded, status: %s", addr, info.Status)
+	}
+
+	minBond, err := GetMinSequencerBondInBaseDenom(hd)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Tokens.AmountOf(consts.Denoms.Hub).LT(minBond.Amount) {
+		return nil, fmt.Errorf(
+			"sequencer %s bond %s is below the minimum bond %s",
+			addr,
+			info.Tokens.String(),
+			minBond.String(),
+		)
+	}
+
+	return info, nil
+}
+
+// WaitForProposer polls the hub until done returns true for the current
+// proposer of the rollapp and returns that proposer, timeout of 0 waits
+// indefinitely
+func WaitForProposer(
+	raID string,
+	hd consts.HubData,
+	interval, timeout time.Duration,
+	done func(proposer string) bool,
+) (string, error) {
+	start := time.Now()
+
+	for {
+		proposer, err := rollapp.GetCurrentProposer(raID, hd)
+		if err == nil && done(strings.TrimSpace(proposer)) {
+			return strings.TrimSpace(proposer), nil
+		}
+
+		if timeout > 0 && time.Since(start) > timeout {
+			return "", errors.New("timed out waiting for the proposer to change")
+		}
+
+		time.Sleep(interval)
+	}
+}
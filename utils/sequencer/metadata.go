@@ -0,0 +1,281 @@
+package sequencer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/utils/jsondiff"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const endpointCheckTimeout = 5 * time.Second
+
+var (
+	urlRegex      = regexp.MustCompile(`^(https?:\/\/)?([\da-z\.-]+)\.([a-z\.]{2,6})(:\d+)?([\/\w \.-]*)*\/?$`)
+	telegramRegex = regexp.MustCompile(`^https:\/\/(t\.me|telegram\.me)\/[\w]{3,}\/?$`)
+	xRegex        = regexp.MustCompile(`^https:\/\/(www\.)?(x\.com|twitter\.com)\/[\w]{1,15}\/?$`)
+)
+
+// MetadataFieldDiff represents a single changed field between two metadata
+// versions, Left holds the current and Right the proposed value
+type MetadataFieldDiff = jsondiff.FieldDiff
+
+func IsValidURL(url string) bool {
+	return urlRegex.MatchString(url)
+}
+
+func LoadMetadataFromFile(path string) (*Metadata, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Metadata
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata file %s: %w", path, err)
+	}
+
+	return &m, nil
+}
+
+// DiffMetadata returns the field level differences between the current and
+// the proposed metadata, sorted by field path
+func DiffMetadata(current, proposed *Metadata) ([]MetadataFieldDiff, error) {
+	c, err := decodeMetadata(current)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decodeMetadata(proposed)
+	if err != nil {
+		return nil, err
+	}
+
+	// unset and empty values are the same on-chain
+	return jsondiff.Compare(c, p, false), nil
+}
+
+func PrintMetadataDiff(diffs []MetadataFieldDiff) {
+	if len(diffs) == 0 {
+		pterm.Info.Println("no changes compared to the on-chain metadata")
+		return
+	}
+
+	td := pterm.TableData{{"Field", "Current", "Proposed"}}
+	for _, d := range diffs {
+		td = append(
+			td, []string{
+				d.Field,
+				pterm.Red(d.Left),
+				pterm.Green(d.Right),
+			},
+		)
+	}
+
+	_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+}
+
+// ValidateMetadata checks the format of all URLs, social links and snapshots
+// in the metadata. When checkEndpoints is set, the RPC, REST and EVM RPC
+// endpoints also have to be reachable
+func ValidateMetadata(m *Metadata, checkEndpoints bool) []error {
+	var errs []error
+
+	checkURLs := func(field string, urls []string) {
+		for i, u := range urls {
+			if !IsValidURL(u) {
+				errs = append(errs, fmt.Errorf("%s.%d: invalid url %q", field, i, u))
+			}
+		}
+	}
+
+	checkURLs("rpcs", m.Rpcs)
+	checkURLs("rest_api_urls", m.RestApiUrls)
+	checkURLs("evm_rpcs", m.EvmRpcs)
+	checkURLs("genesis_urls", m.GenesisUrls)
+
+	if m.ExplorerUrl != "" && !IsValidURL(m.ExplorerUrl) {
+		errs = append(errs, fmt.Errorf("explorer_url: invalid url %q", m.ExplorerUrl))
+	}
+
+	if m.ContactDetails != nil {
+		cd := m.ContactDetails
+		if cd.Website != "" && !IsValidURL(cd.Website) {
+			errs = append(errs, fmt.Errorf("contact_details.website: invalid url %q", cd.Website))
+		}
+		if cd.Telegram != "" && !telegramRegex.MatchString(cd.Telegram) {
+			errs = append(
+				errs,
+				fmt.Errorf(
+					"contact_details.telegram: %q is not a telegram link (https://t.me/<name>)",
+					cd.Telegram,
+				),
+			)
+		}
+		if cd.X != "" && !xRegex.MatchString(cd.X) {
+			errs = append(
+				errs,
+				fmt.Errorf("contact_details.x: %q is not an x link (https://x.com/<handle>)", cd.X),
+			)
+		}
+	}
+
+	for i, s := range m.Snapshots {
+		if s == nil {
+			continue
+		}
+
+		if !IsValidURL(s.SnapshotUrl) {
+			errs = append(errs, fmt.Errorf("snapshots.%d.snapshot_url: invalid url %q", i, s.SnapshotUrl))
+		}
+
+		h, err := strconv.ParseUint(s.Height, 10, 64)
+		if err != nil || h == 0 {
+			errs = append(errs, fmt.Errorf("snapshots.%d.height: invalid height %q", i, s.Height))
+		}
+
+		if !isValidSHA256(s.Checksum) {
+			errs = append(
+				errs,
+				fmt.Errorf("snapshots.%d.checksum: %q is not a sha-256 checksum", i, s.Checksum),
+			)
+		}
+	}
+
+	if !checkEndpoints {
+		return errs
+	}
+
+	for i, u := range m.Rpcs {
+		if err := checkEndpoint(http.MethodGet, withScheme(u)+"/health", nil); err != nil {
+			errs = append(errs, fmt.Errorf("rpcs.%d: %s is unreachable: %w", i, u, err))
+		}
+	}
+
+	for i, u := range m.RestApiUrls {
+		err := checkEndpoint(
+			http.MethodGet,
+			withScheme(u)+"/cosmos/base/tendermint/v1beta1/node_info",
+			nil,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rest_api_urls.%d: %s is unreachable: %w", i, u, err))
+		}
+	}
+
+	for i, u := range m.EvmRpcs {
+		body := []byte(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`)
+		if err := checkEndpoint(http.MethodPost, withScheme(u), body); err != nil {
+			errs = append(errs, fmt.Errorf("evm_rpcs.%d: %s is unreachable: %w", i, u, err))
+		}
+	}
+
+	return errs
+}
+
+func checkEndpoint(method, url string, body []byte) error {
+	client := http.Client{Timeout: endpointCheckTimeout}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func withScheme(u string) string {
+	u = strings.TrimSuffix(u, "/")
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return "https://" + u
+	}
+
+	return u
+}
+
+func isValidSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func decodeMetadata(m *Metadata) (any, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// CheckMetadataFile validates the metadata file, prints any validation errors
+// and returns its diff against the on-chain metadata of the hub sequencer
+func CheckMetadataFile(
+	rollerData roller.RollappConfig,
+	path string,
+	checkEndpoints bool,
+) ([]MetadataFieldDiff, bool, error) {
+	proposed, err := LoadMetadataFromFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if checkEndpoints {
+		pterm.Info.Println("checking endpoint reachability")
+	}
+	errs := ValidateMetadata(proposed, checkEndpoints)
+	for _, e := range errs {
+		pterm.Error.Println(e)
+	}
+
+	address, err := GetHubSequencerAddress(rollerData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current, err := GetMetadata(address, rollerData.HubData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	diffs, err := DiffMetadata(current, proposed)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return diffs, len(errs) == 0, nil
+}
@@ -419,6 +419,15 @@ func GetSequencerData(cfg roller.RollappConfig) ([]keys.AccountData, error) {
 }
 
 func GetSequencerBond(address string, hd consts.HubData) (*cosmossdktypes.Coins, error) {
+	info, err := GetSequencerInfo(address, hd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info.Tokens, nil
+}
+
+func GetSequencerInfo(address string, hd consts.HubData) (*Info, error) {
 	c := exec.Command(
 		consts.Executables.Dymension,
 		"q",
@@ -441,7 +450,7 @@ func GetSequencerBond(address string, hd consts.HubData) (*cosmossdktypes.Coins,
 		return nil, err
 	}
 
-	return &GetSequencerResponse.Sequencer.Tokens, nil
+	return &GetSequencerResponse.Sequencer, nil
 }
 
 func GetDymintFilePath(root string) string {
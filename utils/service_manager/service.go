@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os/exec"
+	"runtime"
 	"sync"
 	"time"
 
@@ -197,3 +198,23 @@ func StopLaunchdService(serviceName string) error {
 
 	return nil
 }
+
+// IsServiceActive reports whether the roller managed service is running
+func IsServiceActive(serviceName string) (bool, error) {
+	switch runtime.GOOS {
+	case "linux":
+		// is-active exits with a non-zero code for inactive services
+		err := exec.Command("systemctl", "is-active", "--quiet", serviceName+".service").Run()
+		return err == nil, nil
+	case "darwin":
+		err := exec.Command(
+			"sudo",
+			"launchctl",
+			"list",
+			fmt.Sprintf("xyz.dymension.roller.%s", serviceName),
+		).Run()
+		return err == nil, nil
+	default:
+		return false, fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+}
@@ -0,0 +1,52 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+// latestVersionKey is the key the cosmos sdk stores the last committed height
+// of the application under
+const latestVersionKey = "s/latest"
+
+func applicationDBPath(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.Rollapp, "data", "application.db")
+}
+
+// StoredHeight returns the last height committed to the application store of
+// a stopped rollapp. It fails while the rollapp runs, the node holds the lock
+// of the store
+func StoredHeight(home string) (int64, error) {
+	db, err := leveldb.OpenFile(
+		applicationDBPath(home),
+		&opt.Options{ReadOnly: true, ErrorIfMissing: true},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the application store: %w", err)
+	}
+	// nolint: errcheck
+	defer db.Close()
+
+	bz, err := db.Get([]byte(latestVersionKey), nil)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return 0, errors.New("the application store has no committed height")
+		}
+		return 0, err
+	}
+
+	var h int64
+	err = gogotypes.StdInt64Unmarshal(&h, bz)
+	if err != nil {
+		return 0, fmt.Errorf("invalid height in the application store: %w", err)
+	}
+
+	return h, nil
+}
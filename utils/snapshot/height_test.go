@@ -0,0 +1,33 @@
+package snapshot
+
+import (
+	"testing"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestStoredHeight(t *testing.T) {
+	home := t.TempDir()
+
+	_, err := StoredHeight(home)
+	require.ErrorContains(t, err, "failed to open the application store")
+
+	db, err := leveldb.OpenFile(applicationDBPath(home), nil)
+	require.NoError(t, err)
+
+	bz, err := gogotypes.StdInt64Marshal(12345)
+	require.NoError(t, err)
+	require.NoError(t, db.Put([]byte(latestVersionKey), bz, nil))
+
+	// the running node holds the lock of the store
+	_, err = StoredHeight(home)
+	require.Error(t, err)
+
+	require.NoError(t, db.Close())
+
+	h, err := StoredHeight(home)
+	require.NoError(t, err)
+	require.Equal(t, int64(12345), h)
+}
@@ -0,0 +1,496 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	// expansionFactor is the assumed ratio between the extracted data and
+	// the compressed archive, used for the disk space pre-check
+	expansionFactor = 3
+
+	maxDownloadRetries = 10
+	retryBackoff       = 5 * time.Second
+
+	privValidatorStateFile = "priv_validator_state.json"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type RestoreOptions struct {
+	// Source is either an http(s) url or a path to a local archive
+	Source string
+	// Checksum is the expected sha-256 of the archive, verification is
+	// skipped when it's empty
+	Checksum string
+	// KeepBackup keeps the previous data directory after a successful restore
+	KeepBackup     bool
+	SkipSpaceCheck bool
+	// OnProgress is called with the number of archive bytes read and the
+	// total size of the archive, which is 0 when unknown
+	OnProgress func(read, total int64)
+}
+
+type RestoreResult struct {
+	BackupDir string
+	Checksum  string
+}
+
+// Restore streams the snapshot archive into the rollapp data directory
+// without staging the archive on disk. Remote downloads resume with http
+// range requests after network errors. The archive is extracted next to the
+// current data directory, which is only replaced once the checksum is
+// verified, so a failed restore leaves the existing data untouched
+func Restore(home string, opts RestoreOptions) (*RestoreResult, error) {
+	rollappDir := filepath.Join(home, consts.ConfigDirName.Rollapp)
+	dataDir := filepath.Join(rollappDir, "data")
+
+	src, size, err := openSource(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:errcheck
+	defer src.Close()
+
+	if !opts.SkipSpaceCheck && size > 0 {
+		err = checkFreeSpace(rollappDir, size*expansionFactor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ts := time.Now().Unix()
+	stagingDir := filepath.Join(rollappDir, fmt.Sprintf(".restore-%d", ts))
+	err = os.MkdirAll(stagingDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:errcheck
+	defer os.RemoveAll(stagingDir)
+
+	hash := sha256.New()
+	pr := &progressReader{r: src, total: size, onProgress: opts.OnProgress}
+	err = extract(io.TeeReader(pr, hash), stagingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// drain any trailing bytes so the checksum covers the complete archive
+	_, err = io.Copy(hash, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := fmt.Sprintf("%x", hash.Sum(nil))
+	if opts.Checksum != "" && !strings.EqualFold(checksum, opts.Checksum) {
+		return nil, fmt.Errorf(
+			"snapshot archive checksum mismatch, have: %s, want: %s",
+			checksum,
+			opts.Checksum,
+		)
+	}
+
+	restoredDataDir := filepath.Join(stagingDir, "data")
+	if _, err := os.Stat(restoredDataDir); err != nil {
+		return nil, errors.New("snapshot archive doesn't contain a data directory")
+	}
+
+	res := &RestoreResult{Checksum: checksum}
+	if _, err := os.Stat(dataDir); err == nil {
+		err = preservePrivValidatorState(dataDir, restoredDataDir)
+		if err != nil {
+			return nil, err
+		}
+
+		res.BackupDir = filepath.Join(rollappDir, fmt.Sprintf("data.backup-%d", ts))
+		err = os.Rename(dataDir, res.BackupDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up the data directory: %w", err)
+		}
+	}
+
+	err = os.Rename(restoredDataDir, dataDir)
+	if err != nil {
+		if res.BackupDir != "" {
+			_ = os.Rename(res.BackupDir, dataDir)
+		}
+		return nil, fmt.Errorf("failed to move the restored data directory: %w", err)
+	}
+
+	if res.BackupDir != "" && !opts.KeepBackup {
+		err = os.RemoveAll(res.BackupDir)
+		if err != nil {
+			return res, fmt.Errorf("failed to remove the backup %s: %w", res.BackupDir, err)
+		}
+		res.BackupDir = ""
+	}
+
+	return res, nil
+}
+
+// Rollback replaces the data directory with the most recent backup created
+// by Restore
+func Rollback(home string) (string, error) {
+	rollappDir := filepath.Join(home, consts.ConfigDirName.Rollapp)
+	backups, err := filepath.Glob(filepath.Join(rollappDir, "data.backup-*"))
+	if err != nil {
+		return "", err
+	}
+	if len(backups) == 0 {
+		return "", errors.New("no data directory backup found")
+	}
+
+	// backups are suffixed with a unix timestamp, the last one is the newest
+	latest := backups[len(backups)-1]
+	dataDir := filepath.Join(rollappDir, "data")
+
+	err = os.RemoveAll(dataDir)
+	if err != nil {
+		return "", err
+	}
+
+	return latest, os.Rename(latest, dataDir)
+}
+
+type privValidatorState struct {
+	Height string `json:"height"`
+	Round  int32  `json:"round"`
+	Step   int8   `json:"step"`
+}
+
+func readPrivValidatorState(path string) (*privValidatorState, []byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pvs privValidatorState
+	err = json.Unmarshal(b, &pvs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid validator state %s: %w", path, err)
+	}
+
+	return &pvs, b, nil
+}
+
+// before reports whether the validator state is behind o, comparing the
+// height, round and step in that order
+func (pvs privValidatorState) before(o privValidatorState) (bool, error) {
+	h, err := strconv.ParseInt(pvs.Height, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid validator state height %q", pvs.Height)
+	}
+	oh, err := strconv.ParseInt(o.Height, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid validator state height %q", o.Height)
+	}
+
+	switch {
+	case h != oh:
+		return h < oh, nil
+	case pvs.Round != o.Round:
+		return pvs.Round < o.Round, nil
+	default:
+		return pvs.Step < o.Step, nil
+	}
+}
+
+// preservePrivValidatorState makes sure the validator state of the node never
+// goes backwards. Snapshots ship the validator state of the node they were
+// taken from, which is usually behind the local one, so the newer of the two
+// is kept. The restore is aborted when the local state can't be read
+func preservePrivValidatorState(from, to string) error {
+	local, b, err := readPrivValidatorState(filepath.Join(from, privValidatorStateFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read the local validator state: %w", err)
+	}
+
+	dst := filepath.Join(to, privValidatorStateFile)
+	restored, _, err := readPrivValidatorState(dst)
+	if err == nil {
+		older, err := local.before(*restored)
+		if err != nil {
+			return err
+		}
+		if older {
+			return nil
+		}
+	}
+
+	err = os.WriteFile(dst, b, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to keep the local validator state: %w", err)
+	}
+
+	return nil
+}
+
+func openSource(source string) (io.ReadCloser, int64, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		r := &resumableReader{url: source}
+		err := r.open()
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, r.size, nil
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, err
+	}
+
+	return f, fi.Size(), nil
+}
+
+func checkFreeSpace(dir string, required int64) error {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(dir, &stat)
+	if err != nil {
+		return fmt.Errorf("failed to check free disk space: %w", err)
+	}
+
+	// nolint:unconvert
+	available := uint64(stat.Bavail) * uint64(stat.Bsize)
+	if available < uint64(required) {
+		return fmt.Errorf(
+			"insufficient disk space in %s: %d MiB available, about %d MiB required",
+			dir,
+			available>>20,
+			required>>20,
+		)
+	}
+
+	return nil
+}
+
+// extract unpacks the `data/` directory of a .tar.gz or .tar.zst stream into
+// destDir, the compression is detected from the stream itself
+func extract(r io.Reader, destDir string) error {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+
+	var dr io.Reader
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		// nolint:errcheck
+		defer gzr.Close()
+		dr = gzr
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		defer zr.Close()
+		dr = zr
+	default:
+		return errors.New("unsupported snapshot archive, only .tar.gz and .tar.zst are supported")
+	}
+
+	tr := tar.NewReader(dr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("tar reading error: %w", err)
+		}
+
+		name := filepath.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name != "data" && !strings.HasPrefix(name, "data"+string(filepath.Separator)) {
+			continue
+		}
+
+		target := filepath.Join(destDir, name)
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in snapshot archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return fmt.Errorf("failed to create symlink %s: %w", target, err)
+			}
+		}
+	}
+
+	// drain the decompressor so the remaining archive bytes reach the hash
+	_, err = io.Copy(io.Discard, dr)
+	return err
+}
+
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(target), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// nolint:gosec
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", target, err)
+	}
+	// nolint:errcheck
+	defer f.Close()
+
+	// nolint:gosec
+	_, err = io.Copy(f, r)
+	if err != nil {
+		return fmt.Errorf("failed to write to file %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// resumableReader reads an http body and transparently reconnects with a
+// range request from the current offset when the connection breaks
+type resumableReader struct {
+	url     string
+	body    io.ReadCloser
+	offset  int64
+	size    int64
+	retries int
+}
+
+func (r *resumableReader) open() error {
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return err
+	}
+	if r.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case r.offset == 0 && resp.StatusCode == http.StatusOK:
+		r.size = resp.ContentLength
+	case r.offset > 0 && resp.StatusCode == http.StatusPartialContent:
+	case r.offset > 0 && resp.StatusCode == http.StatusOK:
+		_ = resp.Body.Close()
+		return errors.New("the server doesn't support resuming downloads")
+	default:
+		_ = resp.Body.Close()
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	r.body = resp.Body
+	return nil
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	for {
+		if r.body == nil {
+			err := r.open()
+			if err != nil {
+				if !r.retry() {
+					return 0, fmt.Errorf("failed to resume download: %w", err)
+				}
+				continue
+			}
+		}
+
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if err == nil || err == io.EOF {
+			if err == io.EOF && r.size > 0 && r.offset < r.size {
+				err = io.ErrUnexpectedEOF
+			} else {
+				return n, err
+			}
+		}
+
+		_ = r.body.Close()
+		r.body = nil
+		if n > 0 {
+			r.retries = 0
+			return n, nil
+		}
+		if !r.retry() {
+			return 0, fmt.Errorf("download failed after %d retries: %w", maxDownloadRetries, err)
+		}
+	}
+}
+
+func (r *resumableReader) retry() bool {
+	if r.retries >= maxDownloadRetries {
+		return false
+	}
+	r.retries++
+	time.Sleep(retryBackoff)
+	return true
+}
+
+func (r *resumableReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
+
+type progressReader struct {
+	r          io.Reader
+	read       int64
+	total      int64
+	onProgress func(read, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.onProgress != nil && n > 0 {
+		p.onProgress(p.read, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,269 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	archiveExt  = ".tar.zst"
+	metadataExt = ".json"
+)
+
+// Snapshot describes a local snapshot archive of the rollapp data directory,
+// stored next to the archive as <archive>.json
+type Snapshot struct {
+	File      string    `json:"file"`
+	Height    string    `json:"height"`
+	Checksum  string    `json:"checksum"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url,omitempty"`
+}
+
+func Dir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.Snapshots)
+}
+
+func (s *Snapshot) Path(home string) string {
+	return filepath.Join(Dir(home), s.File)
+}
+
+func (s *Snapshot) metadataPath(home string) string {
+	return s.Path(home) + metadataExt
+}
+
+func (s *Snapshot) Save(home string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.metadataPath(home), b, 0o644)
+}
+
+// List returns the local snapshots ordered from the newest to the oldest
+func List(home string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(Dir(home))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), archiveExt+metadataExt) {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(Dir(home), e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var s Snapshot
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot metadata %s: %w", e.Name(), err)
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	sort.Slice(
+		snapshots, func(i, j int) bool {
+			return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+		},
+	)
+
+	return snapshots, nil
+}
+
+// Find returns the local snapshot with the provided file name or height
+func Find(home, nameOrHeight string) (*Snapshot, error) {
+	snapshots, err := List(home)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range snapshots {
+		if s.File == nameOrHeight || s.Height == nameOrHeight {
+			return &s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("snapshot %s not found in %s", nameOrHeight, Dir(home))
+}
+
+// Create archives the data directory of the rollapp with tar and zstd. The
+// archive entries are prefixed with `data/`, the layout expected when
+// restoring a snapshot. The node must not be writing to the data directory
+// while the snapshot is created
+func Create(home, raID, height string) (*Snapshot, error) {
+	err := os.MkdirAll(Dir(home), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now().UTC()
+	s := &Snapshot{
+		File:      fmt.Sprintf("%s-%s-%d%s", raID, height, createdAt.Unix(), archiveExt),
+		Height:    height,
+		CreatedAt: createdAt,
+	}
+
+	archivePath := s.Path(home)
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:errcheck
+	defer f.Close()
+
+	hash := sha256.New()
+	zw, err := zstd.NewWriter(io.MultiWriter(f, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	rollappDir := filepath.Join(home, consts.ConfigDirName.Rollapp)
+	err = writeTar(zw, rollappDir, "data")
+	if err != nil {
+		_ = zw.Close()
+		_ = os.Remove(archivePath)
+		return nil, err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	s.Size = fi.Size()
+	s.Checksum = fmt.Sprintf("%x", hash.Sum(nil))
+
+	err = s.Save(home)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func writeTar(w io.Writer, root, dir string) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(
+		filepath.Join(root, dir), func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			name, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+
+			var link string
+			if fi.Mode()&os.ModeSymlink != 0 {
+				link, err = os.Readlink(path)
+				if err != nil {
+					return err
+				}
+			}
+
+			header, err := tar.FileInfoHeader(fi, link)
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(name)
+
+			err = tw.WriteHeader(header)
+			if err != nil {
+				return err
+			}
+
+			if !fi.Mode().IsRegular() {
+				return nil
+			}
+
+			// nolint:gosec
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			// nolint:errcheck
+			defer f.Close()
+
+			_, err = io.Copy(tw, f)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// Remove deletes the snapshot archive and its metadata
+func Remove(home string, s Snapshot) error {
+	err := os.Remove(s.Path(home))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return os.Remove(s.metadataPath(home))
+}
+
+// Prune removes the snapshots exceeding the retention: everything but the
+// newest keep snapshots and everything older than maxAge. A keep of 0 and a
+// maxAge of 0 disable the respective rule. Published snapshots are kept
+// unless includePublished is set, as nodes may still be downloading them
+func Prune(home string, keep int, maxAge time.Duration, includePublished bool) ([]Snapshot, error) {
+	snapshots, err := List(home)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Snapshot
+	for i, s := range snapshots {
+		expired := maxAge > 0 && time.Since(s.CreatedAt) > maxAge
+		exceeding := keep > 0 && i >= keep
+		if !expired && !exceeding {
+			continue
+		}
+
+		if s.URL != "" && !includePublished {
+			continue
+		}
+
+		err := Remove(home, s)
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, s)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,123 @@
+package standby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const staleLockAge = time.Minute
+
+// ErrLeaseHeld is returned when the lease is held by another node
+var ErrLeaseHeld = errors.New("lease is held by another node")
+
+// Lease marks the node that is allowed to run as the sequencer. A standby is
+// only promoted when the lease is free, expired or already held by itself,
+// which prevents two nodes from producing blocks at the same time when the
+// lease file lives on storage shared between them
+type Lease struct {
+	Holder     string    `json:"holder"`
+	Host       string    `json:"host"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (l *Lease) IsExpired() bool {
+	return time.Now().After(l.ExpiresAt)
+}
+
+// ReadLease returns the lease stored at path, or nil when there is none
+func ReadLease(path string) (*Lease, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var l Lease
+	err = json.Unmarshal(b, &l)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lease file %s: %w", path, err)
+	}
+
+	return &l, nil
+}
+
+// AcquireLease takes or renews the lease for the holder. It fails when the
+// lease is held by a different holder and hasn't expired yet
+func AcquireLease(path, holder string, ttl time.Duration) (*Lease, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lock file guards the read-modify-write of the lease against
+	// concurrent acquisitions by other nodes
+	lockPath := path + ".lock"
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		// a lock left behind by a crashed process would block the lease forever
+		fi, statErr := os.Stat(lockPath)
+		if statErr != nil || time.Since(fi.ModTime()) < staleLockAge {
+			return nil, fmt.Errorf("lease %s is locked by another process: %w", path, err)
+		}
+
+		_ = os.Remove(lockPath)
+		lock, err = os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("lease %s is locked by another process: %w", path, err)
+		}
+	}
+	// nolint:errcheck
+	defer os.Remove(lockPath)
+	// nolint:errcheck
+	defer lock.Close()
+
+	current, err := ReadLease(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	l := &Lease{
+		Holder:     holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	l.Host, _ = os.Hostname()
+
+	if current != nil && current.Holder == holder {
+		l.AcquiredAt = current.AcquiredAt
+	} else if current != nil && !current.IsExpired() {
+		return nil, fmt.Errorf(
+			"%w: %s on %s until %s",
+			ErrLeaseHeld,
+			current.Holder,
+			current.Host,
+			current.ExpiresAt.Format(time.RFC3339),
+		)
+	}
+
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, b, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
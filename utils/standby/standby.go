@@ -0,0 +1,182 @@
+package standby
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dymensionxyz/roller/utils/dymint"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	defaultStateUpdateTimeout = 30 * time.Minute
+	defaultBlockTimeout       = 5 * time.Minute
+	defaultCheckInterval      = 30 * time.Second
+	defaultFailureThreshold   = 3
+	defaultLeaseTTL           = 2 * time.Minute
+	defaultLeaseFileName      = "sequencer.lease"
+
+	localRollappRPC = "http://localhost:26657"
+
+	// MinLeaseTTL is the shortest lease ttl accepted. The health agent renews
+	// the lease every third of the ttl and a lock left behind by a crashed
+	// process delays a renewal by up to staleLockAge, the lease must not
+	// expire in between
+	MinLeaseTTL = 2 * staleLockAge
+)
+
+// Settings is the parsed standby configuration with the defaults applied
+type Settings struct {
+	StateUpdateTimeout time.Duration
+	BlockTimeout       time.Duration
+	CheckInterval      time.Duration
+	FailureThreshold   int
+	LeaseFile          string
+	LeaseTTL           time.Duration
+	FenceCommand       string
+	// SharedLease is set when the lease file is configured, the default one
+	// in the roller home isn't visible to the nodes on other hosts
+	SharedLease bool
+}
+
+// CanFence reports whether promotion is protected against a second active
+// sequencer, either by a lease shared with the other nodes or by a fence
+// command
+func (s *Settings) CanFence() bool {
+	return s.SharedLease || s.FenceCommand != ""
+}
+
+func LoadSettings(cfg roller.RollappConfig) (*Settings, error) {
+	sc := cfg.Standby
+	s := &Settings{
+		FailureThreshold: sc.FailureThreshold,
+		LeaseFile:        sc.LeaseFile,
+		FenceCommand:     sc.FenceCommand,
+	}
+
+	durations := []struct {
+		name   string
+		value  string
+		target *time.Duration
+		def    time.Duration
+	}{
+		{"state_update_timeout", sc.StateUpdateTimeout, &s.StateUpdateTimeout, defaultStateUpdateTimeout},
+		{"block_timeout", sc.BlockTimeout, &s.BlockTimeout, defaultBlockTimeout},
+		{"check_interval", sc.CheckInterval, &s.CheckInterval, defaultCheckInterval},
+		{"lease_ttl", sc.LeaseTTL, &s.LeaseTTL, defaultLeaseTTL},
+	}
+
+	for _, d := range durations {
+		if d.value == "" {
+			*d.target = d.def
+			continue
+		}
+
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid standby.%s: %w", d.name, err)
+		}
+		*d.target = v
+	}
+
+	if s.LeaseTTL < MinLeaseTTL {
+		return nil, fmt.Errorf(
+			"invalid standby.lease_ttl: %s is shorter than the minimum of %s",
+			s.LeaseTTL,
+			MinLeaseTTL,
+		)
+	}
+
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = defaultFailureThreshold
+	}
+
+	s.SharedLease = s.LeaseFile != ""
+	if s.LeaseFile == "" {
+		s.LeaseFile = filepath.Join(cfg.Home, defaultLeaseFileName)
+	}
+
+	return s, nil
+}
+
+// CheckResult is the outcome of a single liveness check of the active sequencer
+type CheckResult struct {
+	HubHeight   string
+	LocalHeight int64
+	// HubStale is set when the hub didn't receive a state update within the
+	// state update timeout
+	HubStale bool
+	// P2PStale is set when the local node didn't receive a new block over
+	// P2P within the block timeout
+	P2PStale bool
+	// Failures is the number of consecutive checks with both signals stale
+	Failures int
+}
+
+func (r CheckResult) Failed() bool {
+	return r.HubStale && r.P2PStale
+}
+
+// Monitor tracks the liveness of the active sequencer through the state
+// updates it submits to the hub and the blocks the local node receives
+type Monitor struct {
+	cfg      roller.RollappConfig
+	settings *Settings
+
+	hubHeight       string
+	hubChangedAt    time.Time
+	localHeight     int64
+	localChangedAt  time.Time
+	consecutiveFail int
+}
+
+func NewMonitor(cfg roller.RollappConfig, settings *Settings) *Monitor {
+	now := time.Now()
+	return &Monitor{
+		cfg:            cfg,
+		settings:       settings,
+		hubChangedAt:   now,
+		localChangedAt: now,
+	}
+}
+
+// Check queries both liveness signals. Query errors count as no progress so a
+// hub or local node outage can't hide a dead sequencer, but both signals have
+// to be stale before a check is considered failed
+func (m *Monitor) Check() CheckResult {
+	now := time.Now()
+
+	ra, err := rollapp.Show(m.cfg.RollappID, m.cfg.HubData)
+	if err == nil && ra.Summary.LatestHeight != m.hubHeight {
+		m.hubHeight = ra.Summary.LatestHeight
+		m.hubChangedAt = now
+	}
+
+	h, err := dymint.GetLocalHeight(localRollappRPC)
+	if err == nil && h > m.localHeight {
+		m.localHeight = h
+		m.localChangedAt = now
+	}
+
+	r := CheckResult{
+		HubHeight:   m.hubHeight,
+		LocalHeight: m.localHeight,
+		HubStale:    now.Sub(m.hubChangedAt) > m.settings.StateUpdateTimeout,
+		P2PStale:    now.Sub(m.localChangedAt) > m.settings.BlockTimeout,
+	}
+
+	if r.Failed() {
+		m.consecutiveFail++
+	} else {
+		m.consecutiveFail = 0
+	}
+	r.Failures = m.consecutiveFail
+
+	return r
+}
+
+func (m *Monitor) ShouldPromote() bool {
+	return m.consecutiveFail >= m.settings.FailureThreshold
+}
@@ -0,0 +1,437 @@
+package statenodes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// The pool keeps the custom state nodes and the latest probe of every state
+// node per celestia network. It's stored in the roller home, outside of the DA
+// directory so it survives switching the DA
+const poolFileName = "da-state-nodes.json"
+
+const (
+	// the light node connects to the gRPC endpoint of the state node, the RPC
+	// endpoint is used to check its chain and height
+	rpcPort  = "26657"
+	grpcPort = "9090"
+
+	probeTimeout = 5 * time.Second
+
+	// HealthyTTL is how long the probe of a usable node is trusted, failed
+	// probes are retried sooner so a node that recovers is picked up again
+	HealthyTTL = 10 * time.Minute
+	failedTTL  = 2 * time.Minute
+
+	// celestia produces a block about every 6 seconds, used to estimate the
+	// network height when only some of the probes are refreshed
+	blockTime = 6 * time.Second
+	// a state node further behind than this is considered out of sync
+	maxLag = 10
+	// the current node is only replaced when another one scores at least this
+	// much better, so the light client isn't restarted because of noise
+	switchMargin = 10
+)
+
+// Probe is the result of checking a state node. Latency is the main component
+// of the score, which favours the nodes close to the host
+type Probe struct {
+	Node    string        `json:"node"`
+	ChainID string        `json:"chain_id"`
+	Height  int64         `json:"height"`
+	Lag     int64         `json:"lag"`
+	Latency time.Duration `json:"latency"`
+	RPC     bool          `json:"rpc"`
+	GRPC    bool          `json:"grpc"`
+	// ProbeError is why the node couldn't be reached, Error also covers the
+	// nodes that are reachable but on the wrong chain or out of sync
+	ProbeError string    `json:"probe_error,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Score      int       `json:"score"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+func (p Probe) Usable() bool {
+	return p.Error == ""
+}
+
+func (p Probe) expired(now time.Time) bool {
+	ttl := HealthyTTL
+	if !p.Usable() {
+		ttl = failedTTL
+	}
+
+	return now.Sub(p.CheckedAt) >= ttl
+}
+
+type Pool struct {
+	Custom map[string][]string `json:"custom"`
+	Probes map[string][]Probe  `json:"probes"`
+}
+
+func PoolPath(home string) string {
+	return filepath.Join(home, poolFileName)
+}
+
+func Load(home string) (*Pool, error) {
+	p := &Pool{
+		Custom: map[string][]string{},
+		Probes: map[string][]Probe{},
+	}
+
+	b, err := os.ReadFile(PoolPath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state node pool %s: %w", PoolPath(home), err)
+	}
+	if p.Custom == nil {
+		p.Custom = map[string][]string{}
+	}
+	if p.Probes == nil {
+		p.Probes = map[string][]Probe{}
+	}
+
+	return p, nil
+}
+
+func (p *Pool) Save(home string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(PoolPath(home), b, 0o644)
+}
+
+// NormalizeNode returns the host of a state node, the light node takes the
+// host only and uses the default ports
+func NormalizeNode(node string) (string, error) {
+	n := strings.TrimSpace(node)
+	n = strings.TrimPrefix(n, "http://")
+	n = strings.TrimPrefix(n, "https://")
+	n = strings.TrimSuffix(n, "/")
+
+	if n == "" {
+		return "", errors.New("the state node is empty")
+	}
+	if strings.ContainsAny(n, ":/") {
+		return "", fmt.Errorf(
+			"state node %s should be a host, ports %s (gRPC) and %s (RPC) are used",
+			node,
+			grpcPort,
+			rpcPort,
+		)
+	}
+
+	return n, nil
+}
+
+func (p *Pool) AddCustom(network, node string) (string, error) {
+	n, err := NormalizeNode(node)
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(p.Custom[network], n) {
+		return "", fmt.Errorf("%s is already a custom state node of %s", n, network)
+	}
+
+	p.Custom[network] = append(p.Custom[network], n)
+	return n, nil
+}
+
+func (p *Pool) RemoveCustom(network, node string) (string, error) {
+	n, err := NormalizeNode(node)
+	if err != nil {
+		return "", err
+	}
+
+	i := slices.Index(p.Custom[network], n)
+	if i < 0 {
+		return "", fmt.Errorf("%s is not a custom state node of %s", n, network)
+	}
+
+	p.Custom[network] = slices.Delete(p.Custom[network], i, i+1)
+	p.Probes[network] = slices.DeleteFunc(
+		p.Probes[network], func(pr Probe) bool {
+			return pr.Node == n && !slices.Contains(builtinNodes(network), n)
+		},
+	)
+
+	return n, nil
+}
+
+func builtinNodes(network string) []string {
+	return consts.DaNetworks[network].StateNodes
+}
+
+// Candidates returns the state nodes of the network the rollapp uses without
+// duplicates: the built in ones, the ones of roller.toml and the custom ones
+func (p *Pool) Candidates(rollerData roller.RollappConfig) []string {
+	network := string(rollerData.DA.ID)
+
+	var nodes []string
+	for _, list := range [][]string{
+		builtinNodes(network),
+		rollerData.DA.StateNodes,
+		p.Custom[network],
+	} {
+		for _, n := range list {
+			if n != "" && !slices.Contains(nodes, n) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// Probe checks the candidate state nodes concurrently, the cached probes that
+// didn't expire are reused unless force is set. The probes are returned best
+// first
+func (p *Pool) Probe(rollerData roller.RollappConfig, force bool) ([]Probe, error) {
+	if rollerData.DA.Backend != consts.Celestia {
+		return nil, fmt.Errorf(
+			"state nodes are only probed for %s, the rollapp uses %s",
+			consts.Celestia,
+			rollerData.DA.Backend,
+		)
+	}
+
+	network := string(rollerData.DA.ID)
+	candidates := p.Candidates(rollerData)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%s has no state nodes", network)
+	}
+
+	now := time.Now().UTC()
+	probes := make([]Probe, len(candidates))
+	var wg sync.WaitGroup
+	for i, n := range candidates {
+		j := slices.IndexFunc(p.Probes[network], func(pr Probe) bool { return pr.Node == n })
+		if !force && j >= 0 && !p.Probes[network][j].expired(now) {
+			probes[i] = p.Probes[network][j]
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, n string) {
+			defer wg.Done()
+			probes[i] = probeNode(n)
+		}(i, n)
+	}
+	wg.Wait()
+
+	score(probes, network, now)
+	p.Probes[network] = probes
+
+	return probes, nil
+}
+
+func probeNode(node string) Probe {
+	pr := Probe{
+		Node:      node,
+		CheckedAt: time.Now().UTC(),
+	}
+
+	var wg sync.WaitGroup
+	var rpcErr, grpcErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		pr.ChainID, pr.Height, rpcErr = rpcStatus(node)
+		pr.Latency = time.Since(start)
+	}()
+	go func() {
+		defer wg.Done()
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(node, grpcPort), probeTimeout)
+		if err != nil {
+			grpcErr = err
+			return
+		}
+		// nolint: errcheck
+		conn.Close()
+	}()
+	wg.Wait()
+
+	pr.RPC = rpcErr == nil
+	pr.GRPC = grpcErr == nil
+	switch {
+	case rpcErr != nil:
+		pr.ProbeError = "RPC unreachable: " + rpcErr.Error()
+	case grpcErr != nil:
+		pr.ProbeError = "gRPC unreachable: " + grpcErr.Error()
+	}
+
+	return pr
+}
+
+func rpcStatus(node string) (string, int64, error) {
+	client := http.Client{Timeout: probeTimeout}
+	// nolint: noctx
+	resp, err := client.Get(fmt.Sprintf("http://%s/status", net.JoinHostPort(node, rpcPort)))
+	if err != nil {
+		return "", 0, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var sr struct {
+		Result struct {
+			NodeInfo struct {
+				Network string `json:"network"`
+			} `json:"node_info"`
+			SyncInfo struct {
+				LatestBlockHeight string `json:"latest_block_height"`
+			} `json:"sync_info"`
+		} `json:"result"`
+	}
+	err = json.Unmarshal(body, &sr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid status response: %w", err)
+	}
+
+	h, err := strconv.ParseInt(sr.Result.SyncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid height %q", sr.Result.SyncInfo.LatestBlockHeight)
+	}
+
+	return sr.Result.NodeInfo.Network, h, nil
+}
+
+// score rates the probes from 0 to 100 and sorts them best first. The lag is
+// measured against the tallest node, the heights of older probes are
+// extrapolated to the time of the latest one
+func score(probes []Probe, network string, now time.Time) {
+	estimate := func(pr Probe) int64 {
+		return pr.Height + int64(now.Sub(pr.CheckedAt)/blockTime)
+	}
+
+	var tallest int64
+	for _, pr := range probes {
+		if pr.RPC && pr.ChainID == network {
+			tallest = max(tallest, estimate(pr))
+		}
+	}
+
+	for i := range probes {
+		pr := &probes[i]
+		pr.Score = 0
+		pr.Error = pr.ProbeError
+		if pr.RPC {
+			pr.Lag = max(tallest-estimate(*pr), 0)
+		}
+
+		switch {
+		case pr.Error != "":
+		case pr.ChainID != network:
+			pr.Error = fmt.Sprintf("wrong chain %s", pr.ChainID)
+		case pr.Lag > maxLag:
+			pr.Error = fmt.Sprintf("%d blocks behind", pr.Lag)
+		default:
+			s := 100 - int(pr.Latency.Milliseconds()/10) - int(pr.Lag)*5
+			pr.Score = max(s, 1)
+		}
+	}
+
+	slices.SortStableFunc(
+		probes, func(a, b Probe) int {
+			return b.Score - a.Score
+		},
+	)
+}
+
+// Best returns the best usable probe that isn't excluded
+func Best(probes []Probe, exclude ...string) *Probe {
+	for _, pr := range probes {
+		if pr.Usable() && !slices.Contains(exclude, pr.Node) {
+			return &pr
+		}
+	}
+
+	return nil
+}
+
+// Choose returns the state node the rollapp should use, the current one is
+// kept unless it's unusable or another one scores clearly better
+func Choose(probes []Probe, current string) (*Probe, error) {
+	best := Best(probes)
+	if best == nil {
+		return nil, fmt.Errorf("none of the %d state nodes is usable", len(probes))
+	}
+
+	i := slices.IndexFunc(probes, func(pr Probe) bool { return pr.Node == current })
+	if i >= 0 && probes[i].Usable() && best.Score-probes[i].Score < switchMargin {
+		return &probes[i], nil
+	}
+
+	return best, nil
+}
+
+// SetCurrent points roller.toml at the state node, the light client has to be
+// reloaded to use it
+func SetCurrent(home, node string) error {
+	return tomlconfig.UpdateFieldInFile(roller.GetConfigPath(home), "DA.current_state_node", node)
+}
+
+// Select probes the state nodes of the rollapp, stores the results and makes
+// the chosen one the current state node. It reports whether the current state
+// node changed
+func Select(rollerData roller.RollappConfig, force bool) (*Probe, bool, error) {
+	p, err := Load(rollerData.Home)
+	if err != nil {
+		return nil, false, err
+	}
+
+	probes, err := p.Probe(rollerData, force)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = p.Save(rollerData.Home)
+	if err != nil {
+		return nil, false, err
+	}
+
+	chosen, err := Choose(probes, rollerData.DA.CurrentStateNode)
+	if err != nil {
+		return nil, false, err
+	}
+	if chosen.Node == rollerData.DA.CurrentStateNode {
+		return chosen, false, nil
+	}
+
+	err = SetCurrent(rollerData.Home, chosen.Node)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return chosen, true, nil
+}
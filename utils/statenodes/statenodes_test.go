@@ -0,0 +1,240 @@
+package statenodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const testNetwork = string(consts.CelestiaTestnet)
+
+var testNow = time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+// probe returns a successful probe of the node taken at the time of the test
+func probe(node string, height int64, latency time.Duration) Probe {
+	return Probe{
+		Node:      node,
+		ChainID:   testNetwork,
+		Height:    height,
+		Latency:   latency,
+		RPC:       true,
+		GRPC:      true,
+		CheckedAt: testNow,
+	}
+}
+
+func byNode(probes []Probe) map[string]Probe {
+	m := map[string]Probe{}
+	for _, pr := range probes {
+		m[pr.Node] = pr
+	}
+
+	return m
+}
+
+func TestScore(t *testing.T) {
+	stale := probe("stale", 1000, 50*time.Millisecond)
+	// 60 seconds are 10 celestia blocks, the node was at the network height
+	stale.CheckedAt = testNow.Add(-time.Minute)
+
+	unreachable := probe("unreachable", 0, 0)
+	unreachable.RPC = false
+	unreachable.ProbeError = "RPC unreachable: connection refused"
+
+	noGRPC := probe("no-grpc", 1010, 20*time.Millisecond)
+	noGRPC.GRPC = false
+	noGRPC.ProbeError = "gRPC unreachable: connection refused"
+
+	wrongChain := probe("wrong-chain", 5000, 10*time.Millisecond)
+	wrongChain.ChainID = "celestia"
+
+	probes := []Probe{
+		probe("slow", 1010, 900*time.Millisecond),
+		probe("lagging", 1007, 100*time.Millisecond),
+		unreachable,
+		probe("fast", 1010, 100*time.Millisecond),
+		probe("behind", 990, 10*time.Millisecond),
+		stale,
+		noGRPC,
+		wrongChain,
+		probe("very-slow", 1010, 5*time.Second),
+	}
+
+	score(probes, testNetwork, testNow)
+	got := byNode(probes)
+
+	tests := []struct {
+		node  string
+		score int
+		lag   int64
+		err   string
+	}{
+		{node: "fast", score: 90},
+		{node: "stale", score: 95},
+		{node: "lagging", score: 75, lag: 3},
+		{node: "slow", score: 10},
+		{node: "very-slow", score: 1},
+		{node: "behind", lag: 20, err: "20 blocks behind"},
+		{node: "wrong-chain", err: "wrong chain celestia"},
+		{node: "unreachable", err: "RPC unreachable: connection refused"},
+		{node: "no-grpc", err: "gRPC unreachable: connection refused"},
+	}
+	for _, tc := range tests {
+		t.Run(
+			tc.node, func(t *testing.T) {
+				pr := got[tc.node]
+				require.Equal(t, tc.score, pr.Score)
+				require.Equal(t, tc.lag, pr.Lag)
+				require.Equal(t, tc.err, pr.Error)
+				require.Equal(t, tc.err == "", pr.Usable())
+			},
+		)
+	}
+
+	var order []string
+	for _, pr := range probes {
+		order = append(order, pr.Node)
+	}
+	require.Equal(t, []string{"stale", "fast", "lagging", "slow", "very-slow"}, order[:5])
+}
+
+func TestScoreRescoresCachedProbes(t *testing.T) {
+	// the error of a cached probe is derived again from the probe error, a
+	// node that caught up becomes usable
+	pr := probe("node", 1000, 0)
+	pr.Error = "20 blocks behind"
+	pr.Score = 0
+	probes := []Probe{pr, probe("other", 1000, 0)}
+
+	score(probes, testNetwork, testNow)
+	require.Empty(t, probes[0].Error)
+	require.Equal(t, 100, probes[0].Score)
+}
+
+func TestExpired(t *testing.T) {
+	usable := probe("usable", 1, 0)
+	failed := probe("failed", 1, 0)
+	failed.Error = "wrong chain celestia"
+
+	tests := []struct {
+		name    string
+		probe   Probe
+		age     time.Duration
+		expired bool
+	}{
+		{name: "fresh usable probe", probe: usable, age: HealthyTTL - time.Second},
+		{name: "expired usable probe", probe: usable, age: HealthyTTL, expired: true},
+		{name: "fresh failed probe", probe: failed, age: failedTTL - time.Second},
+		{name: "expired failed probe", probe: failed, age: failedTTL, expired: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Equal(t, tc.expired, tc.probe.expired(testNow.Add(tc.age)))
+			},
+		)
+	}
+}
+
+func TestProbeReusesCachedProbes(t *testing.T) {
+	builtin := consts.DaNetworks[testNetwork].StateNodes
+	require.NotEmpty(t, builtin)
+
+	now := time.Now().UTC()
+	p := &Pool{Custom: map[string][]string{}, Probes: map[string][]Probe{}}
+	for i, n := range builtin {
+		pr := probe(n, 1000, time.Duration(i+1)*10*time.Millisecond)
+		pr.CheckedAt = now.Add(-time.Minute)
+		p.Probes[testNetwork] = append(p.Probes[testNetwork], pr)
+	}
+
+	probes, err := p.Probe(
+		roller.RollappConfig{
+			DA: consts.DaData{Backend: consts.Celestia, ID: consts.CelestiaTestnet},
+		},
+		false,
+	)
+	require.NoError(t, err)
+	require.Len(t, probes, len(builtin))
+	for _, pr := range probes {
+		require.True(t, pr.CheckedAt.Before(now), "%s was probed again", pr.Node)
+		require.True(t, pr.Usable())
+	}
+	require.Equal(t, builtin[0], probes[0].Node)
+}
+
+func TestChoose(t *testing.T) {
+	scored := func(node string, s int) Probe {
+		pr := probe(node, 1, 0)
+		pr.Score = s
+		return pr
+	}
+	failed := scored("failed", 0)
+	failed.Error = "RPC unreachable: connection refused"
+
+	tests := []struct {
+		name    string
+		probes  []Probe
+		current string
+		want    string
+		err     bool
+	}{
+		{
+			name:    "the current node is kept within the margin",
+			probes:  []Probe{scored("best", 90), scored("current", 81)},
+			current: "current",
+			want:    "current",
+		},
+		{
+			name:    "a clearly better node replaces the current one",
+			probes:  []Probe{scored("best", 90), scored("current", 80)},
+			current: "current",
+			want:    "best",
+		},
+		{
+			name:    "an unusable current node is replaced",
+			probes:  []Probe{scored("best", 50), failed},
+			current: "failed",
+			want:    "best",
+		},
+		{
+			name:    "an unknown current node is replaced",
+			probes:  []Probe{scored("best", 50)},
+			current: "removed",
+			want:    "best",
+		},
+		{
+			name:    "no usable node",
+			probes:  []Probe{failed},
+			current: "failed",
+			err:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				chosen, err := Choose(tc.probes, tc.current)
+				if tc.err {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.want, chosen.Node)
+			},
+		)
+	}
+}
+
+func TestBestExcludes(t *testing.T) {
+	probes := []Probe{probe("a", 1, 0), probe("b", 1, 0)}
+
+	require.Equal(t, "a", Best(probes).Node)
+	require.Equal(t, "b", Best(probes, "a").Node)
+	require.Nil(t, Best(probes, "a", "b"))
+}
@@ -0,0 +1,157 @@
+package upgrades
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pterm/pterm"
+)
+
+const ledgerFileName = "migrations.json"
+
+// Ledger records the config migrations applied in a roller home together with
+// the changes they made, which allows reverting them
+type Ledger struct {
+	// Baseline is the rollapp version the configuration was created for,
+	// migrations of older versions are never applied
+	Baseline string        `json:"baseline"`
+	Applied  []LedgerEntry `json:"applied"`
+}
+
+type LedgerEntry struct {
+	ID        string    `json:"id"`
+	Version   string    `json:"version"`
+	AppliedAt time.Time `json:"applied_at"`
+	Changes   []Change  `json:"changes"`
+}
+
+func LedgerPath(home string) string {
+	return filepath.Join(home, ledgerFileName)
+}
+
+// LoadLedger returns the ledger of the roller home, or an empty ledger when no
+// migration was applied yet
+func LoadLedger(home string) (*Ledger, error) {
+	b, err := os.ReadFile(LedgerPath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Ledger{}, nil
+		}
+		return nil, err
+	}
+
+	// numbers are decoded as json.Number to restore integers as integers
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var l Ledger
+	err = d.Decode(&l)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrations ledger %s: %w", LedgerPath(home), err)
+	}
+
+	for i := range l.Applied {
+		for j := range l.Applied[i].Changes {
+			c := &l.Applied[i].Changes[j]
+			c.Old = fromJSONValue(c.Old)
+			c.New = fromJSONValue(c.New)
+		}
+	}
+
+	return &l, nil
+}
+
+func (l *Ledger) Save(home string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(LedgerPath(home), b, 0o644)
+}
+
+// SetBaseline records the rollapp release a new configuration was created for
+func SetBaseline(home, version string) error {
+	l, err := LoadLedger(home)
+	if err != nil {
+		return err
+	}
+
+	l.Baseline = version
+	return l.Save(home)
+}
+
+func (l *Ledger) IsApplied(id string) bool {
+	return l.index(id) >= 0
+}
+
+func (l *Ledger) index(id string) int {
+	for i, e := range l.Applied {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func fromJSONValue(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case []any:
+		for i := range t {
+			t[i] = fromJSONValue(t[i])
+		}
+		return t
+	case map[string]any:
+		for k := range t {
+			t[k] = fromJSONValue(t[k])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// PrintEntries renders the changes of the migrations as a table
+func PrintEntries(entries []LedgerEntry) {
+	for _, e := range entries {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println(e.ID)
+		if len(e.Changes) == 0 {
+			pterm.Info.Println("no changes, the configuration is up to date")
+			continue
+		}
+
+		td := pterm.TableData{{"File", "Key", "Old", "New"}}
+		for _, c := range e.Changes {
+			td = append(
+				td, []string{
+					filepath.Base(c.File),
+					c.Key,
+					pterm.Red(formatValue(c.Old)),
+					pterm.Green(formatValue(c.New)),
+				},
+			)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(td).Render()
+	}
+}
+
+func formatValue(v any) string {
+	if v == nil {
+		return "<unset>"
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
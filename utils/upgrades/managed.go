@@ -0,0 +1,513 @@
+package upgrades
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/dependencies/types"
+)
+
+// Managed upgrades keep every rollapp binary in a versioned directory:
+//
+//	<home>/upgrades/<name>/bin/rollappd
+//	<home>/upgrades/<name>/upgrade.json
+//	<home>/upgrades/current  -> <name>
+//	<home>/upgrades/previous -> <name>
+//
+// and the rollapp executable is a symlink to current/bin/rollappd, so an
+// upgrade or a rollback is an atomic swap of the current symlink
+const (
+	GenesisUpgrade = "genesis"
+
+	currentLink     = "current"
+	previousLink    = "previous"
+	upgradeInfoFile = "upgrade.json"
+)
+
+// StagedUpgrade is a rollapp binary prepared for an upgrade. The name matches
+// the name of the on-chain upgrade plan
+type StagedUpgrade struct {
+	Name     string    `json:"name"`
+	Version  string    `json:"version"`
+	Height   int64     `json:"height,omitempty"`
+	StagedAt time.Time `json:"staged_at"`
+}
+
+// Plan is the upgrade plan scheduled on the rollapp
+type Plan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+func Dir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.Upgrades)
+}
+
+func (u *StagedUpgrade) dir(home string) string {
+	return filepath.Join(Dir(home), u.Name)
+}
+
+func (u *StagedUpgrade) BinaryPath(home string) string {
+	return filepath.Join(u.dir(home), "bin", filepath.Base(consts.Executables.RollappEVM))
+}
+
+func (u *StagedUpgrade) save(home string) error {
+	b, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(u.dir(home), upgradeInfoFile), b, 0o644)
+}
+
+// IsManaged reports whether the rollapp executable is managed by roller
+// upgrades
+func IsManaged(home string) bool {
+	target, err := os.Readlink(consts.Executables.RollappEVM)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(target, Dir(home))
+}
+
+// InstalledVersion returns the version of the installed rollapp binary
+// without network access: the version of the active managed upgrade, the
+// release roller recorded when it installed the binary, or fallback
+func InstalledVersion(home, fallback string) string {
+	if IsManaged(home) {
+		u, err := Current(home)
+		if err == nil && u.Version != "" {
+			return u.Version
+		}
+	}
+
+	v, err := dependencies.InstalledRelease(filepath.Base(consts.Executables.RollappEVM))
+	if err == nil && v != "" {
+		return v
+	}
+
+	return fallback
+}
+
+// Init copies the installed rollapp binary into the genesis upgrade directory
+// and replaces the rollapp executable with a symlink to the current upgrade
+func Init(home, version string) error {
+	if IsManaged(home) {
+		return nil
+	}
+
+	u := &StagedUpgrade{
+		Name:     GenesisUpgrade,
+		Version:  version,
+		StagedAt: time.Now().UTC(),
+	}
+
+	err := copyBinary(consts.Executables.RollappEVM, u.BinaryPath(home))
+	if err != nil {
+		return fmt.Errorf("failed to copy the rollapp binary: %w", err)
+	}
+
+	err = u.save(home)
+	if err != nil {
+		return err
+	}
+
+	err = swapLink(filepath.Join(Dir(home), currentLink), u.Name)
+	if err != nil {
+		return err
+	}
+
+	// not ideal, shouldn't run sudo commands from within roller
+	c := exec.Command(
+		"sudo", "ln", "-sfn",
+		filepath.Join(Dir(home), currentLink, "bin", filepath.Base(consts.Executables.RollappEVM)),
+		consts.Executables.RollappEVM,
+	)
+	_, err = bash.ExecCommandWithStdout(c)
+	if err != nil {
+		return fmt.Errorf("failed to link %s: %w", consts.Executables.RollappEVM, err)
+	}
+
+	return nil
+}
+
+// StageFromSource builds the rollapp binary of the version into the upgrade
+// directory
+func StageFromSource(
+	home, name, version, vmType, bech32Prefix string,
+	height int64,
+) (*StagedUpgrade, error) {
+	dep, err := dependencies.GetRollappDependency(vmType, version, bech32Prefix, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return StageDependency(home, name, dep, height)
+}
+
+// StageDependency installs the rollapp dependency into the upgrade directory,
+// built from source or downloaded from its release
+func StageDependency(
+	home, name string,
+	dep types.Dependency,
+	height int64,
+) (*StagedUpgrade, error) {
+	return stage(
+		home, name, dep.Release, height, func(dest string) error {
+			dep.Binaries = slices.Clone(dep.Binaries)
+			for i := range dep.Binaries {
+				dep.Binaries[i].BinaryDestination = dest
+			}
+
+			return dependencies.InstallDependency(dep)
+		},
+	)
+}
+
+// DependencyStager returns the function installing the rollapp dependency of
+// a managed rollapp as an upgrade named after its version, nil when the rollapp
+// executable isn't managed
+func DependencyStager(home string) dependencies.StageDependency {
+	if !IsManaged(home) {
+		return nil
+	}
+
+	return func(dep types.Dependency) error {
+		if isCurrentVersion(home, dep.Release) {
+			pterm.Info.Printf("[%s] %s is already installed\n", dep.DependencyName, dep.Release)
+			return nil
+		}
+
+		u, err := StageDependency(home, dep.Release, dep, 0)
+		if err != nil {
+			return err
+		}
+		printStaged(u)
+		return nil
+	}
+}
+
+// BinaryStager returns the function installing a prebuilt rollapp binary of a
+// managed rollapp as an upgrade named after its version, nil when the rollapp
+// executable isn't managed
+func BinaryStager(home string) dependencies.StageBinary {
+	if !IsManaged(home) {
+		return nil
+	}
+
+	return func(binary, version string) error {
+		if isCurrentVersion(home, version) {
+			return nil
+		}
+
+		u, err := StageFromBinary(home, version, version, binary, 0)
+		if err != nil {
+			return err
+		}
+		printStaged(u)
+		return nil
+	}
+}
+
+func isCurrentVersion(home, version string) bool {
+	current, err := Current(home)
+	return err == nil && current.Version == version
+}
+
+func printStaged(u *StagedUpgrade) {
+	pterm.Info.Printf(
+		"the rollapp binary is managed, %s was staged as upgrade %s, apply it with %s\n",
+		u.Version,
+		u.Name,
+		pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+			Sprintf("roller rollapp upgrade apply %s", u.Name),
+	)
+}
+
+// StageFromBinary copies a prebuilt rollapp binary into the upgrade directory
+func StageFromBinary(home, name, version, binary string, height int64) (*StagedUpgrade, error) {
+	return stage(
+		home, name, version, height, func(dest string) error {
+			return copyBinary(binary, dest)
+		},
+	)
+}
+
+func stage(
+	home, name, version string,
+	height int64,
+	install func(dest string) error,
+) (*StagedUpgrade, error) {
+	if name == "" || name == currentLink || name == previousLink || name == GenesisUpgrade ||
+		strings.ContainsRune(name, os.PathSeparator) {
+		return nil, fmt.Errorf("invalid upgrade name %q", name)
+	}
+
+	current, err := Current(home)
+	if err == nil && current.Name == name {
+		return nil, fmt.Errorf("%s is the active upgrade", name)
+	}
+
+	u := &StagedUpgrade{
+		Name:     name,
+		Version:  version,
+		Height:   height,
+		StagedAt: time.Now().UTC(),
+	}
+
+	err = os.MkdirAll(filepath.Dir(u.BinaryPath(home)), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = install(u.BinaryPath(home))
+	if err != nil {
+		return nil, err
+	}
+
+	// the binary has to run on this host before it's considered staged
+	out, err := bash.ExecCommandWithStdout(exec.Command(u.BinaryPath(home), "version"))
+	if err != nil {
+		return nil, fmt.Errorf("the staged binary doesn't run: %w", err)
+	}
+	if u.Version == "" {
+		u.Version = strings.TrimSpace(out.String())
+	}
+
+	err = u.save(home)
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func GetStaged(home, name string) (*StagedUpgrade, error) {
+	b, err := os.ReadFile(filepath.Join(Dir(home), name, upgradeInfoFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("upgrade %s is not staged", name)
+		}
+		return nil, err
+	}
+
+	var u StagedUpgrade
+	err = json.Unmarshal(b, &u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upgrade info for %s: %w", name, err)
+	}
+
+	return &u, nil
+}
+
+// ListStaged returns all upgrades, ordered by the time they were staged
+func ListStaged(home string) ([]StagedUpgrade, error) {
+	entries, err := os.ReadDir(Dir(home))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var upgrades []StagedUpgrade
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		u, err := GetStaged(home, e.Name())
+		if err != nil {
+			continue
+		}
+		upgrades = append(upgrades, *u)
+	}
+
+	sort.Slice(
+		upgrades, func(i, j int) bool {
+			return upgrades[i].StagedAt.Before(upgrades[j].StagedAt)
+		},
+	)
+
+	return upgrades, nil
+}
+
+// Current returns the active upgrade
+func Current(home string) (*StagedUpgrade, error) {
+	return linked(home, currentLink)
+}
+
+// Previous returns the upgrade that was active before the current one
+func Previous(home string) (*StagedUpgrade, error) {
+	return linked(home, previousLink)
+}
+
+func linked(home, link string) (*StagedUpgrade, error) {
+	name, err := os.Readlink(filepath.Join(Dir(home), link))
+	if err != nil {
+		return nil, fmt.Errorf("no %s upgrade: %w", link, err)
+	}
+
+	return GetStaged(home, name)
+}
+
+// Activate points the current symlink to the upgrade and remembers the
+// previously active upgrade for rollbacks
+func Activate(home, name string) (*StagedUpgrade, error) {
+	u, err := GetStaged(home, name)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Current(home)
+	if err != nil {
+		return nil, err
+	}
+	if current.Name == u.Name {
+		return nil, fmt.Errorf("%s is already active", name)
+	}
+
+	err = swapLink(filepath.Join(Dir(home), previousLink), current.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = swapLink(filepath.Join(Dir(home), currentLink), u.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Rollback reactivates the previous upgrade and reverts the config migrations
+// recorded for all versions newer than the previous one, an upgrade can span
+// several versions
+func Rollback(home, vmType string) (*StagedUpgrade, error) {
+	prev, err := Previous(home)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = RevertNewerThan(home, vmType, prev.Version, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = swapLink(filepath.Join(Dir(home), currentLink), prev.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Remove(filepath.Join(Dir(home), previousLink))
+	if err != nil {
+		return nil, err
+	}
+
+	return prev, nil
+}
+
+// QueryPlan returns the upgrade plan scheduled on the rollapp, or nil when
+// there is none
+func QueryPlan(rpc string) (*Plan, error) {
+	c := exec.Command(
+		consts.Executables.RollappEVM,
+		"q", "upgrade", "plan",
+		"--node", rpc,
+		"-o", "json",
+	)
+
+	out, err := bash.ExecCommandWithStdout(c)
+	if err != nil {
+		if strings.Contains(err.Error(), "no upgrade scheduled") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var p Plan
+	err = json.Unmarshal(out.Bytes(), &p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Name == "" {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+// HaltHeight is the last height committed before the node halts for the
+// upgrade
+func (p *Plan) HaltHeight() (int64, error) {
+	h, err := strconv.ParseInt(p.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upgrade height %s: %w", p.Height, err)
+	}
+
+	return h - 1, nil
+}
+
+// swapLink atomically points link to target
+func swapLink(link, target string) error {
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+
+	err := os.Symlink(target, tmp)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, link)
+}
+
+func copyBinary(src, dst string) error {
+	err := os.MkdirAll(filepath.Dir(dst), 0o755)
+	if err != nil {
+		return err
+	}
+
+	err = copyFile(src, dst)
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(dst, 0o755)
+}
+
+func copyFile(src, dst string) error {
+	// nolint:gosec
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	// nolint:errcheck
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	// nolint:errcheck
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,393 @@
+package upgrades
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+)
+
+// Migration is the set of configuration changes a rollapp version introduces
+// to a single config file. The ID is stable across roller releases and is
+// recorded in the ledger once the migration is applied
+type Migration struct {
+	ID      string
+	Version string
+	Module  UpgradeModule
+}
+
+// Change is a single key change in a config file. A nil Old value means the
+// key didn't exist before, a nil New value means the key was removed
+type Change struct {
+	File string `json:"file"`
+	Key  string `json:"key"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// VersionsFor returns the versions with configuration changes of the rollapp
+// vm type
+func VersionsFor(vmType string) []Version {
+	switch vmType {
+	case string(consts.EVM_ROLLAPP):
+		return EvmRollappUpgradeModules
+	case string(consts.WASM_ROLLAPP):
+		return WasmRollappUpgradeModules
+	default:
+		return nil
+	}
+}
+
+// MigrationsFor returns the migrations of the vm type in the order of the
+// version index
+func MigrationsFor(vmType string) ([]Migration, error) {
+	var migrations []Migration
+	for _, v := range VersionsFor(vmType) {
+		for _, m := range v.Modules {
+			migrations = append(
+				migrations, Migration{
+					ID:      fmt.Sprintf("%s/%s/%s", vmType, v.VersionIdentifier, m.Name),
+					Version: v.VersionIdentifier,
+					Module:  m,
+				},
+			)
+		}
+	}
+
+	var sortErr error
+	sort.SliceStable(
+		migrations, func(i, j int) bool {
+			c, err := CompareVersions(vmType, migrations[i].Version, migrations[j].Version)
+			if err != nil {
+				sortErr = err
+			}
+			return c < 0
+		},
+	)
+
+	return migrations, sortErr
+}
+
+// Pending returns the migrations of versions newer than the ledger baseline,
+// up to and including the target version, that weren't applied yet
+func Pending(l *Ledger, vmType, target string) ([]Migration, error) {
+	migrations, err := MigrationsFor(vmType)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if l.IsApplied(m.ID) {
+			continue
+		}
+
+		afterBaseline, err := CompareVersions(vmType, m.Version, l.Baseline)
+		if err != nil {
+			return nil, err
+		}
+		beforeTarget, err := CompareVersions(vmType, m.Version, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if afterBaseline > 0 && beforeTarget <= 0 {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+// Run computes the changes of the migration and writes them to the config
+// file unless dryRun is set. Keys that are already migrated are skipped, so
+// running a migration twice doesn't change anything
+func (m Migration) Run(dryRun bool) ([]Change, error) {
+	path := m.Module.ConfigFilePath
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %v", path, err)
+	}
+
+	var changes []Change
+	set := func(key string, v any) {
+		v = normalizeValue(v)
+		var old any
+		if tree.Has(key) {
+			old = tree.Get(key)
+			if reflect.DeepEqual(old, v) {
+				return
+			}
+		}
+
+		tree.Set(key, v)
+		changes = append(changes, Change{File: path, Key: key, Old: old, New: v})
+	}
+	remove := func(key string) any {
+		if !tree.Has(key) {
+			return nil
+		}
+
+		old := tree.Get(key)
+		_ = tree.Delete(key)
+		changes = append(changes, Change{File: path, Key: key, Old: old})
+		return old
+	}
+
+	values := m.Module.Values
+	for _, nv := range values.NewValues {
+		set(nv.Path, nv.Value)
+	}
+
+	for _, uv := range values.UpgradeableValues {
+		if !tree.Has(uv.OldValuePath) {
+			if uv.Value != nil && !tree.Has(uv.NewValuePath) {
+				set(uv.NewValuePath, uv.Value)
+			}
+			continue
+		}
+
+		old := remove(uv.OldValuePath)
+		if uv.Value != nil {
+			old = uv.Value
+		}
+		set(uv.NewValuePath, old)
+	}
+
+	for _, dv := range values.DeprecatedValues {
+		remove(dv)
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	return changes, tomlconfig.WriteTomlTreeToFile(tree, path)
+}
+
+// Revert undoes the changes recorded for a migration in reverse order and
+// returns the changes made to revert them
+func Revert(e LedgerEntry, dryRun bool) ([]Change, error) {
+	trees := make(map[string]*toml.Tree)
+	var changes []Change
+
+	for i := len(e.Changes) - 1; i >= 0; i-- {
+		c := e.Changes[i]
+
+		tree, ok := trees[c.File]
+		if !ok {
+			t, err := toml.LoadFile(c.File)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s: %v", c.File, err)
+			}
+			tree = t
+			trees[c.File] = tree
+		}
+
+		var current any
+		if tree.Has(c.Key) {
+			current = tree.Get(c.Key)
+		}
+
+		if c.Old == nil {
+			_ = tree.Delete(c.Key)
+		} else {
+			tree.Set(c.Key, c.Old)
+		}
+		changes = append(changes, Change{File: c.File, Key: c.Key, Old: current, New: c.Old})
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	for path, tree := range trees {
+		err := tomlconfig.WriteTomlTreeToFile(tree, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// Migrate applies the pending migrations up to the target version and records
+// them in the ledger. The ledger baseline is initialized with from when the
+// ledger doesn't exist yet
+func Migrate(home, vmType, from, target string, dryRun bool) ([]LedgerEntry, error) {
+	l, err := LoadLedger(home)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.Baseline == "" {
+		if from == "" {
+			return nil, fmt.Errorf("the version to migrate from is unknown")
+		}
+		l.Baseline = from
+	}
+
+	pending, err := Pending(l, vmType, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LedgerEntry
+	for _, m := range pending {
+		changes, err := m.Run(dryRun)
+		if err != nil {
+			return entries, fmt.Errorf("migration %s failed: %w", m.ID, err)
+		}
+
+		e := LedgerEntry{
+			ID:        m.ID,
+			Version:   m.Version,
+			AppliedAt: time.Now().UTC(),
+			Changes:   changes,
+		}
+		entries = append(entries, e)
+
+		if dryRun {
+			continue
+		}
+
+		l.Applied = append(l.Applied, e)
+		err = l.Save(home)
+		if err != nil {
+			return entries, err
+		}
+	}
+
+	return entries, nil
+}
+
+// RecordBinaryVersion writes the commit of the installed rollapp binary to
+// roller.toml. The start commands compare it with the binary, the release of
+// the configuration is tracked by the ledger
+func RecordBinaryVersion(home, vmType string) error {
+	raUpgrade, err := NewRollappUpgrade(vmType)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the installed rollapp version: %w", err)
+	}
+
+	return tomlconfig.UpdateFieldInFile(
+		filepath.Join(home, consts.RollerConfigFileName),
+		"rollapp_binary_version",
+		raUpgrade.CurrentVersionCommit,
+	)
+}
+
+// RevertVersion reverts the recorded migrations of the version, the most
+// recent one first
+func RevertVersion(home, version string, dryRun bool) ([]LedgerEntry, error) {
+	l, err := LoadLedger(home)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for i := len(l.Applied) - 1; i >= 0; i-- {
+		if l.Applied[i].Version == version {
+			ids = append(ids, l.Applied[i].ID)
+		}
+	}
+
+	return revertAll(home, ids, dryRun)
+}
+
+// RevertNewerThan reverts the recorded migrations of all versions newer than
+// the version, the most recent one first
+func RevertNewerThan(home, vmType, version string, dryRun bool) ([]LedgerEntry, error) {
+	l, err := LoadLedger(home)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for i := len(l.Applied) - 1; i >= 0; i-- {
+		c, err := CompareVersions(vmType, l.Applied[i].Version, version)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 {
+			ids = append(ids, l.Applied[i].ID)
+		}
+	}
+
+	return revertAll(home, ids, dryRun)
+}
+
+func revertAll(home string, ids []string, dryRun bool) ([]LedgerEntry, error) {
+	var reverted []LedgerEntry
+	for _, id := range ids {
+		e, err := RevertMigration(home, id, dryRun)
+		if err != nil {
+			return reverted, err
+		}
+		reverted = append(reverted, *e)
+	}
+
+	return reverted, nil
+}
+
+// RevertMigration reverts a single recorded migration and removes it from the
+// ledger. The returned entry holds the changes made by the revert
+func RevertMigration(home, id string, dryRun bool) (*LedgerEntry, error) {
+	l, err := LoadLedger(home)
+	if err != nil {
+		return nil, err
+	}
+
+	i := l.index(id)
+	if i < 0 {
+		return nil, fmt.Errorf("migration %s is not applied", id)
+	}
+
+	changes, err := Revert(l.Applied[i], dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert %s: %w", id, err)
+	}
+
+	e := &LedgerEntry{
+		ID:        id,
+		Version:   l.Applied[i].Version,
+		AppliedAt: time.Now().UTC(),
+		Changes:   changes,
+	}
+
+	if dryRun {
+		return e, nil
+	}
+
+	l.Applied = append(l.Applied[:i], l.Applied[i+1:]...)
+	return e, l.Save(home)
+}
+
+// normalizeValue converts values to the types returned by the toml parser,
+// so a value that is already set isn't considered a change
+func normalizeValue(v any) any {
+	switch t := v.(type) {
+	case int:
+		return int64(t)
+	case int32:
+		return int64(t)
+	case uint:
+		return int64(t)
+	case float32:
+		return float64(t)
+	case []string:
+		out := make([]any, len(t))
+		for i, s := range t {
+			out[i] = s
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,58 @@
+package upgrades
+
+import (
+	"fmt"
+	"slices"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+// VersionIndex lists the known rollapp releases of each vm type from the
+// oldest to the newest. It's bundled with roller so migrations can be ordered
+// without network access, releases missing from the index are ordered by
+// their semantic version
+var VersionIndex = map[string][]string{
+	string(consts.EVM_ROLLAPP): {
+		"v2.2.0-hotfix.1",
+		"v2.2.1-rc05",
+	},
+	string(consts.WASM_ROLLAPP): {
+		"v1.0.0-rc04",
+		"v1.0.0-rc05",
+	},
+}
+
+// IsRelease reports whether the version can be ordered by CompareVersions
+func IsRelease(vmType, v string) bool {
+	return slices.Contains(VersionIndex[vmType], v) || semver.IsValid(v)
+}
+
+// CompareVersions returns -1, 0 or 1 when a is older than, equal to or newer
+// than b. Commits can't be ordered offline and result in an error
+func CompareVersions(vmType, a, b string) (int, error) {
+	if a == b {
+		return 0, nil
+	}
+
+	index := VersionIndex[vmType]
+	ai, bi := slices.Index(index, a), slices.Index(index, b)
+	if ai >= 0 && bi >= 0 {
+		if ai < bi {
+			return -1, nil
+		}
+		return 1, nil
+	}
+
+	for _, v := range []string{a, b} {
+		if !semver.IsValid(v) {
+			return 0, fmt.Errorf(
+				"%s is not a release in the version index, commits can't be ordered, provide the release tag instead",
+				v,
+			)
+		}
+	}
+
+	return semver.Compare(a, b), nil
+}